@@ -0,0 +1,311 @@
+// Package deques provides a double-ended queue backed by a growable ring
+// buffer and various functions useful with deques of any type.
+//
+// A deque is not safe for concurrent use. Package syncs provides a bounded
+// queue that is.
+package deques
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/internal"
+	"github.com/shayanh/gcl/iters"
+)
+
+const minCapacity = 8
+
+// Deque is a double-ended queue. Elements are stored in a circular buffer
+// that grows when it is full and only shrinks when Shrink is called.
+type Deque[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+// New creates a new deque and returns a pointer to it.
+func New[T any](elems ...T) *Deque[T] {
+	d := &Deque[T]{}
+	PushBack(d, elems...)
+	return d
+}
+
+// FromIter builds a new deque from the given iterator.
+func FromIter[T any](it iters.Iterator[T]) *Deque[T] {
+	d := New[T]()
+	for it.HasNext() {
+		PushBack(d, it.Next())
+	}
+	return d
+}
+
+func (d *Deque[T]) String() string {
+	var b strings.Builder
+	b.WriteString("deques.Deque[")
+	it := Iter(d)
+	for it.HasNext() {
+		v := it.Next()
+		fmt.Fprintf(&b, "%v", v)
+		if it.HasNext() {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func (d *Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toSlice(d))
+}
+
+func (d *Deque[T]) UnmarshalJSON(b []byte) error {
+	var slice []T
+	if err := json.Unmarshal(b, &slice); err != nil {
+		return err
+	}
+	*d = *New(slice...)
+	return nil
+}
+
+// Len returns size of the given deque. This function is O(1).
+func Len[T any](d *Deque[T]) int {
+	return d.size
+}
+
+// Cap returns the number of elements the deque can hold before it grows.
+// This function is O(1).
+func Cap[T any](d *Deque[T]) int {
+	return len(d.buf)
+}
+
+// Iter returns an forward iterator to the beginning. Initially, the returned
+// iterator is located at one step before the first element (one-before-first).
+func Iter[T any](d *Deque[T]) *FrwIter[T] {
+	return &FrwIter[T]{
+		deque: d,
+		index: -1,
+	}
+}
+
+// IterMut returns a forward iterator to the beginning with mutable pointers.
+// Initially, the returned iterator is located at one step before the first
+// element (one-before-first).
+func IterMut[T any](d *Deque[T]) *FrwIterMut[T] {
+	return &FrwIterMut[T]{
+		deque: d,
+		index: -1,
+	}
+}
+
+// RIter returns a reverse iterator going from the end to the beginning.
+// Initially, the returned iterator is located at one step past the last element
+// (one-past-last).
+func RIter[T any](d *Deque[T]) *RevIter[T] {
+	return &RevIter[T]{
+		deque: d,
+		index: d.size,
+	}
+}
+
+// RIterMut returns a reverse iterator going from the end to the beginning with
+// mutable pointers. Initially, the returned iterator is located at one step
+// past the last element (one-past-last).
+func RIterMut[T any](d *Deque[T]) *RevIterMut[T] {
+	return &RevIterMut[T]{
+		deque: d,
+		index: d.size,
+	}
+}
+
+// Equal tests whether two deques are equal: the same length and all elements
+// equal. Floating point NaNs are not considered equal.
+// This function is O(min(Len(d1), Len(d2))).
+func Equal[T comparable](d1, d2 *Deque[T]) bool {
+	if d1.size != d2.size {
+		return false
+	}
+	for i := 0; i < d1.size; i++ {
+		if *d1.at(i) != *d2.at(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualFunc tests whether two deques are equal using the given `eq` function.
+// For each pair of elements, `eq` determines if they are equal or not.
+// This function is O(f * min(Len(d1), Len(d2))), where f is the time
+// complexity of `eq` function.
+func EqualFunc[T1 any, T2 any](d1 *Deque[T1], d2 *Deque[T2], eq gcl.EqualFn[T1, T2]) bool {
+	if d1.size != d2.size {
+		return false
+	}
+	for i := 0; i < d1.size; i++ {
+		if !eq(*d1.at(i), *d2.at(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// PushBack appends the given elements to the back of deque `d`.
+// This function is O(Len(elems)) amortized. So for a single element it would
+// be O(1) amortized.
+func PushBack[T any](d *Deque[T], elems ...T) {
+	d.reserve(d.size + len(elems))
+	for _, elem := range elems {
+		*d.at(d.size) = elem
+		d.size += 1
+	}
+}
+
+// PushFront appends the given elements to the beginning of deque `d`. The
+// elements keep their order, so after PushFront(d, 1, 2) the deque starts
+// with 1, 2.
+// This function is O(Len(elems)) amortized. So for a single element it would
+// be O(1) amortized.
+func PushFront[T any](d *Deque[T], elems ...T) {
+	d.reserve(d.size + len(elems))
+	for i := len(elems) - 1; i >= 0; i-- {
+		d.head = d.physical(-1)
+		d.buf[d.head] = elems[i]
+		d.size += 1
+	}
+}
+
+// PopBack deletes the last element in the deque. It requires the deque to be
+// non-empty, otherwise it panics.
+// This function is O(1).
+func PopBack[T any](d *Deque[T]) {
+	require(d.size > 0, "deque cannot be empty")
+	var zero T
+	*d.at(d.size - 1) = zero
+	d.size -= 1
+}
+
+// PopFront deletes the first element in the deque. It requires the deque to be
+// non-empty, otherwise it panics.
+// This function is O(1).
+func PopFront[T any](d *Deque[T]) {
+	require(d.size > 0, "deque cannot be empty")
+	var zero T
+	d.buf[d.head] = zero
+	d.head = d.physical(1)
+	d.size -= 1
+}
+
+// Front returns the first element in the deque. It panics if the given deque
+// is empty.
+// This function is O(1).
+func Front[T any](d *Deque[T]) T {
+	require(d.size > 0, "deque cannot be empty")
+	return *d.at(0)
+}
+
+// Back returns the last element in the deque. It panics if the given deque is
+// empty.
+// This function is O(1).
+func Back[T any](d *Deque[T]) T {
+	require(d.size > 0, "deque cannot be empty")
+	return *d.at(d.size - 1)
+}
+
+// At returns the i-th element of the deque, counting from the front. It
+// requires 0 <= i < Len(d), otherwise it panics.
+// This function is O(1).
+func At[T any](d *Deque[T], i int) T {
+	require(0 <= i && i < d.size, "index out of range")
+	return *d.at(i)
+}
+
+// Set sets the i-th element of the deque, counting from the front, to v. It
+// requires 0 <= i < Len(d), otherwise it panics.
+// This function is O(1).
+func Set[T any](d *Deque[T], i int, v T) {
+	require(0 <= i && i < d.size, "index out of range")
+	*d.at(i) = v
+}
+
+// Reverse reverses the elements of the given deque.
+// This function is O(n), where n is length of the deque.
+func Reverse[T any](d *Deque[T]) {
+	internal.Reverse[T](IterMut(d), RIterMut(d))
+}
+
+// Shrink reduces the capacity of the deque to fit its elements. The elements
+// are moved to the beginning of a new buffer, so pointers returned by mutable
+// iterators no longer refer to the deque.
+// This function is O(n), where n is length of the deque.
+func Shrink[T any](d *Deque[T]) {
+	d.resize(d.size)
+}
+
+// Clone returns a copy of the given deque. The elements are copied using
+// assignment, so this is a shallow clone.
+// This function is O(n), where n is length of the deque.
+func Clone[T any](d *Deque[T]) *Deque[T] {
+	res := &Deque[T]{}
+	res.buf = toSlice(d)
+	res.size = d.size
+	return res
+}
+
+// physical returns the position in buf of the i-th element, where i can be
+// in the range [-len(buf), len(buf)).
+func (d *Deque[T]) physical(i int) int {
+	p := d.head + i
+	if p >= len(d.buf) {
+		p -= len(d.buf)
+	} else if p < 0 {
+		p += len(d.buf)
+	}
+	return p
+}
+
+func (d *Deque[T]) at(i int) *T {
+	return &d.buf[d.physical(i)]
+}
+
+// reserve makes sure that the deque can hold n elements without growing.
+func (d *Deque[T]) reserve(n int) {
+	if n <= len(d.buf) {
+		return
+	}
+	capacity := len(d.buf)
+	if capacity < minCapacity {
+		capacity = minCapacity
+	}
+	for capacity < n {
+		capacity *= 2
+	}
+	d.resize(capacity)
+}
+
+// resize moves the elements to the beginning of a new buffer of the given
+// capacity.
+func (d *Deque[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+	if d.size > 0 {
+		if end := d.head + d.size; end <= len(d.buf) {
+			copy(buf, d.buf[d.head:end])
+		} else {
+			n := copy(buf, d.buf[d.head:])
+			copy(buf[n:], d.buf[:end-len(d.buf)])
+		}
+	}
+	d.buf = buf
+	d.head = 0
+}
+
+func toSlice[T any](d *Deque[T]) []T {
+	var res []T
+	if d.size > 0 {
+		res = make([]T, d.size)
+	}
+	for i := range res {
+		res[i] = *d.at(i)
+	}
+	return res
+}
@@ -0,0 +1,201 @@
+package deques
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+)
+
+var pushTests = []struct {
+	d     *Deque[int]
+	back  []int
+	front []int
+	want  *Deque[int]
+}{
+	{
+		New[int](),
+		[]int{1, 2, 3},
+		nil,
+		New(1, 2, 3),
+	},
+	{
+		New(3, 4),
+		nil,
+		[]int{1, 2},
+		New(1, 2, 3, 4),
+	},
+	{
+		New(5, 6, 7, 8, 9, 10, 11),
+		[]int{12, 13},
+		[]int{1, 2, 3, 4},
+		New(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13),
+	},
+}
+
+func TestPush(t *testing.T) {
+	for _, test := range pushTests {
+		cloned := Clone(test.d)
+		PushBack(cloned, test.back...)
+		PushFront(cloned, test.front...)
+		if !Equal(cloned, test.want) {
+			t.Errorf("PushBack(%v, %v), PushFront(%v) got %v, want %v", test.d, test.back, test.front, cloned, test.want)
+		}
+	}
+}
+
+func TestPop(t *testing.T) {
+	d := New(1, 2, 3, 4)
+	PopFront(d)
+	PopBack(d)
+	if want := New(2, 3); !Equal(d, want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+	if Front(d) != 2 || Back(d) != 3 {
+		t.Errorf("Front(%v), Back(%v) = %v, %v, want = 2, 3", d, d, Front(d), Back(d))
+	}
+}
+
+func TestWrapAround(t *testing.T) {
+	d := New[int]()
+	var want []int
+	// Alternate between both ends so that the head wraps around the buffer
+	// several times while it grows.
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			PushFront(d, i)
+			want = append([]int{i}, want...)
+		} else {
+			PushBack(d, i)
+			want = append(want, i)
+		}
+		if i%5 == 0 {
+			PopBack(d)
+			want = want[:len(want)-1]
+		}
+	}
+	if !iters.Equal[int](Iter(d), goslices.Iter(want)) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+	if !iters.Equal[int](RIter(d), goslices.RIter(want)) {
+		t.Errorf("RIter(%v) does not match %v", d, want)
+	}
+	for i, v := range want {
+		if At(d, i) != v {
+			t.Fatalf("At(d, %v) = %v, want = %v", i, At(d, i), v)
+		}
+	}
+}
+
+func TestAtSet(t *testing.T) {
+	d := New(1, 2, 3)
+	PushFront(d, 0)
+	Set(d, 0, 10)
+	Set(d, 3, 30)
+	if want := New(10, 1, 2, 30); !Equal(d, want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+}
+
+func TestIterMut(t *testing.T) {
+	d := New(1, 2, 3)
+	it := IterMut(d)
+	for it.HasNext() {
+		v := it.Next()
+		*v = *v + 1
+	}
+	rit := RIterMut(d)
+	*rit.Next() = 0
+	if want := New(2, 3, 0); !Equal(d, want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+}
+
+var reverseTests = []struct {
+	d    *Deque[int]
+	want *Deque[int]
+}{
+	{
+		New[int](),
+		New[int](),
+	},
+	{
+		New(1, 2, 3),
+		New(3, 2, 1),
+	},
+	{
+		New(1, 2, 3, 4),
+		New(4, 3, 2, 1),
+	},
+}
+
+func TestReverse(t *testing.T) {
+	for _, test := range reverseTests {
+		cloned := Clone(test.d)
+		if Reverse(cloned); !Equal(cloned, test.want) {
+			t.Errorf("Reverse(%v) got %v, want %v", test.d, cloned, test.want)
+		}
+	}
+}
+
+func TestShrink(t *testing.T) {
+	d := New[int]()
+	for i := 0; i < 100; i++ {
+		PushBack(d, i)
+	}
+	for i := 0; i < 97; i++ {
+		PopFront(d)
+	}
+	Shrink(d)
+	if Cap(d) != 3 {
+		t.Errorf("Cap(%v) = %v, want = %v", d, Cap(d), 3)
+	}
+	if want := New(97, 98, 99); !Equal(d, want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+	PushFront(d, 96)
+	if want := New(96, 97, 98, 99); !Equal(d, want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+}
+
+func TestJSONSerialization(t *testing.T) {
+	d1 := New(1, 2, 3)
+	PushFront(d1, 0)
+
+	m, err := json.Marshal(d1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(m) != "[0,1,2,3]" {
+		t.Errorf("json.Marshal(%v) = %s, want = %s", d1, m, "[0,1,2,3]")
+	}
+
+	var d2 *Deque[int]
+	err = json.Unmarshal(m, &d2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !Equal(d1, d2) {
+		t.Error("Wrong JSON serialization")
+	}
+}
+
+func TestIterRandomAccess(t *testing.T) {
+	d := New(2, 3, 4)
+	PushFront(d, 1)
+	var it iters.RandomAccessIterator[int] = Iter(d)
+	if pos, found := iters.BinarySearch(it, 3); pos != 2 || !found {
+		t.Errorf("BinarySearch(%v, 3) = %v, %v, want = 2, true", d, pos, found)
+	}
+	if v := it.Next(); v != 3 || it.Prev() != 2 || it.Remaining() != 2 {
+		t.Error("wrong FrwIter Prev behavior")
+	}
+	rit := RIter(d)
+	rit.Next()
+	if rit.Remaining() != 3 || rit.HasPrev() {
+		t.Errorf("rit.Remaining() = %v, want = 3", rit.Remaining())
+	}
+}
@@ -0,0 +1,170 @@
+package deques
+
+func require(check bool, failMsg string) {
+	if !check {
+		panic(failMsg)
+	}
+}
+
+// FrwIter is a deque forward iterator. FrwIter implements
+// iters.RandomAccessIterator[T].
+type FrwIter[T any] struct {
+	deque *Deque[T]
+	index int
+}
+
+func (it *FrwIter[T]) HasNext() bool {
+	return it.index+1 < it.deque.size
+}
+
+func (it *FrwIter[T]) Next() T {
+	require(it.HasNext(), "iterator must have next")
+
+	it.index += 1
+	return *it.deque.at(it.index)
+}
+
+func (it *FrwIter[T]) HasPrev() bool {
+	return it.index > 0
+}
+
+func (it *FrwIter[T]) Prev() T {
+	require(it.HasPrev(), "iterator must have prev")
+
+	it.index -= 1
+	return *it.deque.at(it.index)
+}
+
+func (it *FrwIter[T]) Remaining() int {
+	return it.deque.size - it.index - 1
+}
+
+func (it *FrwIter[T]) Len() int {
+	return it.deque.size
+}
+
+func (it *FrwIter[T]) Index() int {
+	return it.index
+}
+
+func (it *FrwIter[T]) Seek(i int) {
+	require(-1 <= i && i < it.deque.size, "index out of range")
+	it.index = i
+}
+
+// FrwIterMut is a mutable forward iterator for deques. It allows mutations by
+// returning pointers to the deque elements. FrwIterMut is an iterator over
+// pointers of type T. In other words, FrwIterMut[T] implements
+// iters.Iterator[*T] and iters.RandomAccessIterator[*T].
+type FrwIterMut[T any] struct {
+	deque *Deque[T]
+	index int
+}
+
+func (it *FrwIterMut[T]) HasNext() bool {
+	return it.index+1 < it.deque.size
+}
+
+func (it *FrwIterMut[T]) Next() *T {
+	require(it.HasNext(), "iterator must have next")
+
+	it.index += 1
+	return it.deque.at(it.index)
+}
+
+func (it *FrwIterMut[T]) HasPrev() bool {
+	return it.index > 0
+}
+
+func (it *FrwIterMut[T]) Prev() *T {
+	require(it.HasPrev(), "iterator must have prev")
+
+	it.index -= 1
+	return it.deque.at(it.index)
+}
+
+func (it *FrwIterMut[T]) Remaining() int {
+	return it.deque.size - it.index - 1
+}
+
+func (it *FrwIterMut[T]) Len() int {
+	return it.deque.size
+}
+
+func (it *FrwIterMut[T]) Index() int {
+	return it.index
+}
+
+func (it *FrwIterMut[T]) Seek(i int) {
+	require(-1 <= i && i < it.deque.size, "index out of range")
+	it.index = i
+}
+
+// RevIter is a deque reverse iterator. RevIter implements
+// iters.BidiIterator[T] and iters.SizedIterator[T], where moving forward
+// means going toward the front of the deque.
+type RevIter[T any] struct {
+	deque *Deque[T]
+	index int
+}
+
+func (it *RevIter[T]) HasNext() bool {
+	return it.index > 0
+}
+
+func (it *RevIter[T]) Next() T {
+	require(it.HasNext(), "iterator must have next")
+
+	it.index -= 1
+	return *it.deque.at(it.index)
+}
+
+func (it *RevIter[T]) HasPrev() bool {
+	return it.index+1 < it.deque.size
+}
+
+func (it *RevIter[T]) Prev() T {
+	require(it.HasPrev(), "iterator must have prev")
+
+	it.index += 1
+	return *it.deque.at(it.index)
+}
+
+func (it *RevIter[T]) Remaining() int {
+	return it.index
+}
+
+// RevIterMut is a mutable reverse iterator for deques. It allows mutations by
+// returning pointers to the deque elements. RevIterMut is an iterator over
+// pointers of type T. In other words, RevIterMut[T] implements
+// iters.Iterator[*T].
+type RevIterMut[T any] struct {
+	deque *Deque[T]
+	index int
+}
+
+func (it *RevIterMut[T]) HasNext() bool {
+	return it.index > 0
+}
+
+func (it *RevIterMut[T]) Next() *T {
+	require(it.HasNext(), "iterator must have next")
+
+	it.index -= 1
+	return it.deque.at(it.index)
+}
+
+func (it *RevIterMut[T]) HasPrev() bool {
+	return it.index+1 < it.deque.size
+}
+
+func (it *RevIterMut[T]) Prev() *T {
+	require(it.HasPrev(), "iterator must have prev")
+
+	it.index += 1
+	return it.deque.at(it.index)
+}
+
+func (it *RevIterMut[T]) Remaining() int {
+	return it.index
+}
@@ -44,6 +44,10 @@ func Compare[T constraints.Ordered](a, b T) int {
 	return 1
 }
 
+// HashFn defines a hash function for values of any type. Values that are
+// equal must have the same hash.
+type HashFn[T any] func(T) uint64
+
 // Number contains all the different numeric types.
 type Number interface {
 	constraints.Integer | constraints.Float | constraints.Complex
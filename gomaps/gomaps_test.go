@@ -3,10 +3,8 @@ package gomaps_test
 import (
 	"testing"
 
-	"github.com/shayanh/gcl"
 	"github.com/shayanh/gcl/gomaps"
-	"github.com/shayanh/gcl/goslices"
-	"github.com/shayanh/gcl/iters"
+	"golang.org/x/exp/maps"
 )
 
 func TestIter(t *testing.T) {
@@ -15,13 +13,17 @@ func TestIter(t *testing.T) {
 		"2": 2,
 		"3": 3,
 	}
+	// Go map iteration order is randomized, so the elements are collected
+	// back into a map before comparison.
 	it := gomaps.Iter(m)
-	want := goslices.Iter([]gcl.MapElem[string, int]{
-		{Key: "1", Value: 1},
-		{Key: "2", Value: 2},
-		{Key: "3", Value: 3},
-	})
-	if !iters.Equal[gcl.MapElem[string, int]](it, want) {
+	n := 0
+	got := make(map[string]int)
+	for it.HasNext() {
+		elem := it.Next()
+		got[elem.Key] = elem.Value
+		n++
+	}
+	if n != len(m) || !maps.Equal(got, m) {
 		t.Error("Wrong Iter result")
 	}
 }
@@ -0,0 +1,41 @@
+//go:build go1.23
+
+package gomaps
+
+import "iter"
+
+// All returns an iter.Seq2 over key-value pairs of the given map. The
+// iteration order is not specified.
+func All[M ~map[K]V, K comparable, V any](m M) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iter.Seq over the keys of the given map. The iteration
+// order is not specified.
+func Keys[M ~map[K]V, K comparable, V any](m M) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iter.Seq over the values of the given map. The iteration
+// order is not specified.
+func Values[M ~map[K]V, K comparable, V any](m M) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
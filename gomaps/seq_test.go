@@ -0,0 +1,30 @@
+//go:build go1.23
+
+package gomaps_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/shayanh/gcl/gomaps"
+)
+
+func TestAll(t *testing.T) {
+	m := map[string]int{
+		"1": 1,
+		"2": 2,
+		"3": 3,
+	}
+	if got := maps.Collect(gomaps.All(m)); !maps.Equal(got, m) {
+		t.Errorf("All(%v) yielded %v", m, got)
+	}
+	keys := slices.Sorted(gomaps.Keys(m))
+	if want := []string{"1", "2", "3"}; !slices.Equal(keys, want) {
+		t.Errorf("Keys(%v) yielded %v, want %v", m, keys, want)
+	}
+	values := slices.Sorted(gomaps.Values(m))
+	if want := []int{1, 2, 3}; !slices.Equal(values, want) {
+		t.Errorf("Values(%v) yielded %v, want %v", m, values, want)
+	}
+}
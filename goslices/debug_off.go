@@ -0,0 +1,15 @@
+//go:build !gcldebug
+
+package goslices
+
+// sliceVersion is zero-sized and the version functions are no-ops unless the
+// gcldebug build tag is set. See debug_on.go.
+type sliceVersion struct{}
+
+func changed[T any](s []T) {}
+
+func versionOf[T any](s []T) sliceVersion {
+	return sliceVersion{}
+}
+
+func (v sliceVersion) check() {}
@@ -0,0 +1,106 @@
+//go:build gcldebug
+
+package goslices
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// sliceKey identifies a view of a backing array by the address of its first
+// element and its length, so that sub-slices sharing the first element are
+// told apart.
+type sliceKey struct {
+	addr   uintptr
+	length int
+}
+
+type versionEntry struct {
+	// n is the number of times a slice header with this key has been changed
+	// by the functions of this package.
+	n uint64
+	// refs is the number of live iterators with this key.
+	refs int
+}
+
+// versions holds an entry for every key that live iterators use. Changes of
+// slices that no iterator uses are not recorded, and an entry is removed once
+// its last iterator is garbage collected, so the map does not grow beyond the
+// number of live iterators.
+var versions = struct {
+	sync.Mutex
+	m map[sliceKey]*versionEntry
+}{m: make(map[sliceKey]*versionEntry)}
+
+// sliceVersion is the version of a slice as seen by an iterator.
+type sliceVersion struct {
+	ref *versionRef
+}
+
+// versionRef is allocated separately from the iterator, so that a finalizer
+// can release its entry when the iterator is gone.
+type versionRef struct {
+	key sliceKey
+	n   uint64
+}
+
+func keyOf[T any](s []T) sliceKey {
+	if cap(s) == 0 {
+		return sliceKey{}
+	}
+	return sliceKey{
+		addr:   uintptr(unsafe.Pointer(&s[:cap(s)][0])),
+		length: len(s),
+	}
+}
+
+// changed records that the header of slice s is replaced by a new one.
+func changed[T any](s []T) {
+	key := keyOf(s)
+	versions.Lock()
+	if e := versions.m[key]; e != nil {
+		e.n++
+	}
+	versions.Unlock()
+}
+
+func versionOf[T any](s []T) sliceVersion {
+	key := keyOf(s)
+	if key.addr == 0 {
+		return sliceVersion{}
+	}
+	versions.Lock()
+	e := versions.m[key]
+	if e == nil {
+		e = &versionEntry{}
+		versions.m[key] = e
+	}
+	e.refs++
+	ref := &versionRef{key: key, n: e.n}
+	versions.Unlock()
+	runtime.SetFinalizer(ref, release)
+	return sliceVersion{ref: ref}
+}
+
+func release(ref *versionRef) {
+	versions.Lock()
+	defer versions.Unlock()
+	e := versions.m[ref.key]
+	e.refs--
+	if e.refs == 0 {
+		delete(versions.m, ref.key)
+	}
+}
+
+func (v sliceVersion) check() {
+	if v.ref == nil {
+		return
+	}
+	versions.Lock()
+	n := versions.m[v.ref.key].n
+	versions.Unlock()
+	if n != v.ref.n {
+		panic("goslices: iterator used after its slice header was changed")
+	}
+}
@@ -0,0 +1,66 @@
+//go:build gcldebug
+
+package goslices
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestStaleIterator(t *testing.T) {
+	s := []int{1, 2, 3}
+	it := Iter(s)
+	it.Next()
+	s = PopFront(s)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("using an iterator after PopFront must panic")
+			}
+		}()
+		it.Next()
+	}()
+
+	// Built-in append is not observed, so the old iterator keeps working on
+	// its own view of the slice.
+	it = Iter(s)
+	s = append(s, 4)
+	if it.Next() != 2 || len(s) != 3 {
+		t.Error("an iterator must keep its view after built-in append")
+	}
+}
+
+func TestSubsliceIterator(t *testing.T) {
+	s := []int{1, 2, 3}
+	it := Iter(s[:2])
+	s = PopBack(s)
+	if it.Next() != 1 || len(s) != 2 {
+		t.Error("an iterator of a sub-slice must not be affected by PopBack of its parent")
+	}
+}
+
+func TestVersionsReleased(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		s := make([]int, 3)
+		Iter(s).Next()
+		PopBack(s)
+	}
+	for _, s := range [][]int{{1}, {2}} {
+		PopFront(s)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		runtime.GC()
+		versions.Lock()
+		n := len(versions.m)
+		versions.Unlock()
+		if n == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%v version entries are left after the iterators are gone", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -1,4 +1,12 @@
 // Package goslices provides iterators and operations for built-in go slices.
+//
+// When built with the gcldebug build tag, an iterator panics if it is used
+// after the header of its slice was replaced by PushFront, PopBack or
+// PopFront. Changes made with built-in operations, such as append or
+// reslicing, cannot be observed and are not detected. Slices are identified
+// by the address of their first element and their length, so a change of one
+// slice is also reported to iterators of another slice with the same start
+// and length, but not to iterators of its sub-slices.
 package goslices
 
 import (
@@ -10,8 +18,9 @@ import (
 // iterator is located at one step before the first element (one-before-first).
 func Iter[S ~[]T, T any](s S) *FrwIter[T] {
 	return &FrwIter[T]{
-		slice: s,
-		index: -1,
+		version: versionOf(s),
+		slice:   s,
+		index:   -1,
 	}
 }
 
@@ -20,8 +29,9 @@ func Iter[S ~[]T, T any](s S) *FrwIter[T] {
 // element (one-before-first).
 func IterMut[S ~[]T, T any](s S) *FrwIterMut[T] {
 	return &FrwIterMut[T]{
-		slice: s,
-		index: -1,
+		version: versionOf(s),
+		slice:   s,
+		index:   -1,
 	}
 }
 
@@ -30,8 +40,9 @@ func IterMut[S ~[]T, T any](s S) *FrwIterMut[T] {
 // (one-past-last).
 func RIter[S ~[]T, T any](s S) *RevIter[T] {
 	return &RevIter[T]{
-		slice: s,
-		index: len(s),
+		version: versionOf(s),
+		slice:   s,
+		index:   len(s),
 	}
 }
 
@@ -40,13 +51,18 @@ func RIter[S ~[]T, T any](s S) *RevIter[T] {
 // past the last element (one-past-last).
 func RIterMut[S ~[]T, T any](s S) *RevIterMut[T] {
 	return &RevIterMut[T]{
-		slice: s,
-		index: len(s),
+		version: versionOf(s),
+		slice:   s,
+		index:   len(s),
 	}
 }
 
-// FromIter builds a new slices from the given iterator.
+// FromIter builds a new slices from the given iterator. If the given iterator
+// is an iters.SizedIterator, the resulting slice is allocated once.
 func FromIter[T any](it iters.Iterator[T]) (res []T) {
+	if sit, ok := it.(iters.SizedIterator[T]); ok && sit.Remaining() > 0 {
+		res = make([]T, 0, sit.Remaining())
+	}
 	for it.HasNext() {
 		res = append(res, it.Next())
 	}
@@ -57,6 +73,7 @@ func FromIter[T any](it iters.Iterator[T]) (res []T) {
 // and returns the resulting slice.
 // This function is O(len(s) + len(elems)).
 func PushFront[S ~[]T, T any](s S, elems ...T) S {
+	changed(s)
 	return append(elems, s...)
 }
 
@@ -64,6 +81,7 @@ func PushFront[S ~[]T, T any](s S, elems ...T) S {
 // slice. PopBack requires the given slice to be non-empty.
 // This function is O(1).
 func PopBack[S ~[]T, T any](s S) S {
+	changed(s)
 	return s[0 : len(s)-1]
 }
 
@@ -71,6 +89,7 @@ func PopBack[S ~[]T, T any](s S) S {
 // slices. PopFront requires the slice to be non-empty, otherwise it panics.
 // This function is O(1).
 func PopFront[S ~[]T, T any](s S) S {
+	changed(s)
 	return s[1:]
 }
 
@@ -89,5 +108,5 @@ func Back[S ~[]T, T any](s S) T {
 // Reverse reverses the elements of a slice and returns the resulting slice.
 // This function is O(n), where n is length of the given slice.
 func Reverse[S ~[]T, T any](s S) {
-	internal.Reverse[T](IterMut(s), RIterMut(s), len(s))
+	internal.Reverse[T](IterMut(s), RIterMut(s))
 }
@@ -1,9 +1,17 @@
 package goslices
 
-// FrwIter is a slice forward iterator.
+func checkSeek(i, length int) {
+	if i < -1 || i >= length {
+		panic("index out of range")
+	}
+}
+
+// FrwIter is a slice forward iterator. FrwIter implements
+// iters.RandomAccessIterator[T].
 type FrwIter[T any] struct {
-	slice []T
-	index int
+	version sliceVersion
+	slice   []T
+	index   int
 }
 
 func (it *FrwIter[T]) HasNext() bool {
@@ -11,17 +19,47 @@ func (it *FrwIter[T]) HasNext() bool {
 }
 
 func (it *FrwIter[T]) Next() T {
+	it.version.check()
 	it.index += 1
 	return it.slice[it.index]
 }
 
+func (it *FrwIter[T]) HasPrev() bool {
+	return it.index > 0
+}
+
+func (it *FrwIter[T]) Prev() T {
+	it.version.check()
+	it.index -= 1
+	return it.slice[it.index]
+}
+
+func (it *FrwIter[T]) Remaining() int {
+	return len(it.slice) - it.index - 1
+}
+
+func (it *FrwIter[T]) Len() int {
+	return len(it.slice)
+}
+
+func (it *FrwIter[T]) Index() int {
+	return it.index
+}
+
+func (it *FrwIter[T]) Seek(i int) {
+	it.version.check()
+	checkSeek(i, len(it.slice))
+	it.index = i
+}
+
 // FrwIterMut is a mutable forward iterator for slices. It allows mutations by
 // returning pointers to the slice elements. FrwIterMut is an iterator over
 // pointers of type T. In other words, FrwIterMut[T] implements
-// iters.Iterator[*T].
+// iters.Iterator[*T] and iters.RandomAccessIterator[*T].
 type FrwIterMut[T any] struct {
-	slice []T
-	index int
+	version sliceVersion
+	slice   []T
+	index   int
 }
 
 func (it *FrwIterMut[T]) HasNext() bool {
@@ -29,14 +67,46 @@ func (it *FrwIterMut[T]) HasNext() bool {
 }
 
 func (it *FrwIterMut[T]) Next() *T {
+	it.version.check()
 	it.index += 1
 	return &it.slice[it.index]
 }
 
-// RevIter is a slice reverse iterator.
+func (it *FrwIterMut[T]) HasPrev() bool {
+	return it.index > 0
+}
+
+func (it *FrwIterMut[T]) Prev() *T {
+	it.version.check()
+	it.index -= 1
+	return &it.slice[it.index]
+}
+
+func (it *FrwIterMut[T]) Remaining() int {
+	return len(it.slice) - it.index - 1
+}
+
+func (it *FrwIterMut[T]) Len() int {
+	return len(it.slice)
+}
+
+func (it *FrwIterMut[T]) Index() int {
+	return it.index
+}
+
+func (it *FrwIterMut[T]) Seek(i int) {
+	it.version.check()
+	checkSeek(i, len(it.slice))
+	it.index = i
+}
+
+// RevIter is a slice reverse iterator. RevIter implements
+// iters.BidiIterator[T] and iters.SizedIterator[T], where moving forward
+// means going toward the beginning of the slice.
 type RevIter[T any] struct {
-	slice []T
-	index int
+	version sliceVersion
+	slice   []T
+	index   int
 }
 
 func (it *RevIter[T]) HasNext() bool {
@@ -44,17 +114,33 @@ func (it *RevIter[T]) HasNext() bool {
 }
 
 func (it *RevIter[T]) Next() T {
+	it.version.check()
 	it.index -= 1
 	return it.slice[it.index]
 }
 
+func (it *RevIter[T]) HasPrev() bool {
+	return it.index+1 < len(it.slice)
+}
+
+func (it *RevIter[T]) Prev() T {
+	it.version.check()
+	it.index += 1
+	return it.slice[it.index]
+}
+
+func (it *RevIter[T]) Remaining() int {
+	return it.index
+}
+
 // RevIterMut is a mutable reverse iterator for slices. It allows mutations by
 // returning pointers to the slice elements. RevIterMut is an iterator over
 // pointers of type T. In other words, RevIterMut[T] implements
-// iters.Iterator[*T].
+// iters.Iterator[*T], iters.BidiIterator[*T] and iters.SizedIterator[*T].
 type RevIterMut[T any] struct {
-	slice []T
-	index int
+	version sliceVersion
+	slice   []T
+	index   int
 }
 
 func (it *RevIterMut[T]) HasNext() bool {
@@ -62,6 +148,21 @@ func (it *RevIterMut[T]) HasNext() bool {
 }
 
 func (it *RevIterMut[T]) Next() *T {
+	it.version.check()
 	it.index -= 1
 	return &it.slice[it.index]
 }
+
+func (it *RevIterMut[T]) HasPrev() bool {
+	return it.index+1 < len(it.slice)
+}
+
+func (it *RevIterMut[T]) Prev() *T {
+	it.version.check()
+	it.index += 1
+	return &it.slice[it.index]
+}
+
+func (it *RevIterMut[T]) Remaining() int {
+	return it.index
+}
@@ -0,0 +1,49 @@
+package goslices
+
+import (
+	"testing"
+
+	"github.com/shayanh/gcl/iters"
+)
+
+func TestIterPrev(t *testing.T) {
+	s := []int{1, 2, 3}
+	it := Iter(s)
+	if it.HasPrev() {
+		t.Error("a fresh iterator must not have prev")
+	}
+	it.Next()
+	it.Next()
+	if !it.HasPrev() || it.Prev() != 1 {
+		t.Error("Prev after two Next calls must return the first element")
+	}
+	if it.HasPrev() || it.Remaining() != 2 {
+		t.Errorf("it.HasPrev(), it.Remaining() = %v, %v, want = false, 2", it.HasPrev(), it.Remaining())
+	}
+
+	rit := RIter(s)
+	rit.Next()
+	rit.Next()
+	if !rit.HasPrev() || rit.Prev() != 3 || rit.Remaining() != 2 {
+		t.Error("wrong RevIter Prev behavior")
+	}
+}
+
+func TestIterSeek(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	var it iters.RandomAccessIterator[int] = Iter(s)
+	it.Seek(2)
+	if it.Index() != 2 || it.Next() != 4 || it.HasNext() {
+		t.Error("wrong Seek behavior")
+	}
+	it.Seek(-1)
+	if it.Remaining() != it.Len() || it.Next() != 1 {
+		t.Error("Seek(-1) must reset the iterator")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Seek out of range must panic")
+		}
+	}()
+	it.Seek(4)
+}
@@ -0,0 +1,41 @@
+//go:build go1.23
+
+package goslices
+
+import "iter"
+
+// All returns an iter.Seq2 over index-value pairs of the given slice in the
+// usual order.
+func All[S ~[]T, T any](s S) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range s {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iter.Seq over the values of the given slice in the usual
+// order.
+func Values[S ~[]T, T any](s S) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iter.Seq2 over index-value pairs of the given slice,
+// traversing it backward with descending indices.
+func Backward[S ~[]T, T any](s S) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := len(s) - 1; i >= 0; i-- {
+			if !yield(i, s[i]) {
+				return
+			}
+		}
+	}
+}
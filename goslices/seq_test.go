@@ -0,0 +1,37 @@
+//go:build go1.23
+
+package goslices
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	s := []string{"a", "b", "c"}
+	var got []string
+	for i, v := range All(s) {
+		if s[i] != v {
+			t.Errorf("All(%v) yielded (%v, %v)", s, i, v)
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, s) {
+		t.Errorf("All(%v) yielded %v", s, got)
+	}
+
+	got = nil
+	for i, v := range Backward(s) {
+		if s[i] != v {
+			t.Errorf("Backward(%v) yielded (%v, %v)", s, i, v)
+		}
+		got = append(got, v)
+	}
+	if want := []string{"c", "b", "a"}; !slices.Equal(got, want) {
+		t.Errorf("Backward(%v) yielded %v, want %v", s, got, want)
+	}
+
+	if got := slices.Collect(Values(s)); !slices.Equal(got, s) {
+		t.Errorf("Values(%v) yielded %v", s, got)
+	}
+}
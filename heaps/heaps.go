@@ -0,0 +1,213 @@
+// Package heaps provides a binary heap based priority queue and various
+// functions useful with heaps of any type.
+//
+// A heap is not safe for concurrent use without external synchronization.
+package heaps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/iters"
+)
+
+// Handle refers to an element in a heap. A handle is returned when an element
+// is pushed, and it can be used to update or remove that element later. A
+// handle stays valid until its element is popped or removed from the heap.
+type Handle[T any] struct {
+	value T
+	index int
+	heap  *Heap[T]
+}
+
+// Value returns the value of the element that handle h refers to.
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// Heap is a priority queue implemented as a binary heap. The element at the
+// top of the heap is the smallest element as determined by the `less`
+// function of the heap.
+type Heap[T any] struct {
+	items []*Handle[T]
+	less  gcl.LessFn[T]
+}
+
+// New creates a new heap ordered by the `less` function, containing the given
+// elements, and returns a pointer to it.
+// This function is O(len(elems)).
+func New[T any](less gcl.LessFn[T], elems ...T) *Heap[T] {
+	h := &Heap[T]{
+		items: make([]*Handle[T], len(elems)),
+		less:  less,
+	}
+	for i, elem := range elems {
+		h.items[i] = &Handle[T]{value: elem, index: i, heap: h}
+	}
+	h.heapify()
+	return h
+}
+
+// FromIter builds a new heap ordered by the `less` function from the given
+// iterator.
+// This function is O(n), where n is the number of elements in the iterator.
+func FromIter[T any](it iters.Iterator[T], less gcl.LessFn[T]) *Heap[T] {
+	h := New(less)
+	for it.HasNext() {
+		h.items = append(h.items, &Handle[T]{value: it.Next(), index: len(h.items), heap: h})
+	}
+	h.heapify()
+	return h
+}
+
+func (h *Heap[T]) String() string {
+	var b strings.Builder
+	b.WriteString("heaps.Heap[")
+	for i, item := range h.items {
+		fmt.Fprintf(&b, "%v", item.value)
+		if i+1 < len(h.items) {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Len returns the number of elements in the given heap. This function is O(1).
+func Len[T any](h *Heap[T]) int {
+	return len(h.items)
+}
+
+// Push adds v to the heap and returns a handle to it.
+// This function is O(log(n)), where n is size of the heap.
+func Push[T any](h *Heap[T], v T) *Handle[T] {
+	handle := &Handle[T]{value: v, index: len(h.items), heap: h}
+	h.items = append(h.items, handle)
+	h.up(handle.index)
+	return handle
+}
+
+// Peek returns the top (smallest) element of the heap. It requires the heap
+// to be non-empty, otherwise it panics.
+// This function is O(1).
+func Peek[T any](h *Heap[T]) T {
+	require(len(h.items) > 0, "heap cannot be empty")
+	return h.items[0].value
+}
+
+// Pop removes and returns the top (smallest) element of the heap. It requires
+// the heap to be non-empty, otherwise it panics.
+// This function is O(log(n)), where n is size of the heap.
+func Pop[T any](h *Heap[T]) T {
+	require(len(h.items) > 0, "heap cannot be empty")
+	return h.remove(0)
+}
+
+// Update sets the value of the element that the given handle refers to, and
+// restores the heap order. The handle must belong to heap h, otherwise Update
+// panics.
+// This function is O(log(n)), where n is size of the heap.
+func Update[T any](h *Heap[T], handle *Handle[T], v T) {
+	require(handle.heap == h, "handle does not belong to the heap")
+	handle.value = v
+	if !h.down(handle.index) {
+		h.up(handle.index)
+	}
+}
+
+// Remove removes the element that the given handle refers to and returns its
+// value. The handle must belong to heap h, otherwise Remove panics.
+// This function is O(log(n)), where n is size of the heap.
+func Remove[T any](h *Heap[T], handle *Handle[T]) T {
+	require(handle.heap == h, "handle does not belong to the heap")
+	return h.remove(handle.index)
+}
+
+// Drain returns an iterator that pops the elements of the given heap in
+// priority order, smallest first. Drain is lazy, each call to Next pops one
+// element, so after consuming the whole iterator the heap is empty.
+func Drain[T any](h *Heap[T]) *DrainIter[T] {
+	return &DrainIter[T]{
+		heap: h,
+	}
+}
+
+// Clone returns a copy of the given heap. The elements are copied using
+// assignment, so this is a shallow clone. Handles of h do not refer to the
+// elements of the returned heap.
+// This function is O(n), where n is size of the heap.
+func Clone[T any](h *Heap[T]) *Heap[T] {
+	res := &Heap[T]{
+		items: make([]*Handle[T], len(h.items)),
+		less:  h.less,
+	}
+	for i, item := range h.items {
+		res.items[i] = &Handle[T]{value: item.value, index: i, heap: res}
+	}
+	return res
+}
+
+func (h *Heap[T]) lessAt(i, j int) bool {
+	return h.less(h.items[i].value, h.items[j].value)
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *Heap[T]) heapify() {
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.lessAt(i, parent) {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at index i down the heap. It returns true if the
+// element has moved.
+func (h *Heap[T]) down(i int) bool {
+	start := i
+	n := len(h.items)
+	for {
+		smallest := 2*i + 1
+		if smallest >= n {
+			break
+		}
+		if right := smallest + 1; right < n && h.lessAt(right, smallest) {
+			smallest = right
+		}
+		if !h.lessAt(smallest, i) {
+			break
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+	return i > start
+}
+
+func (h *Heap[T]) remove(i int) T {
+	last := len(h.items) - 1
+	item := h.items[i]
+	if i != last {
+		h.swap(i, last)
+	}
+	h.items[last] = nil
+	h.items = h.items[:last]
+	if i != last && !h.down(i) {
+		h.up(i)
+	}
+	item.heap = nil
+	return item.value
+}
@@ -0,0 +1,125 @@
+package heaps
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/goslices"
+	"golang.org/x/exp/slices"
+)
+
+func TestPushPop(t *testing.T) {
+	h := New(gcl.Less[int])
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		Push(h, v)
+	}
+	if Len(h) != 5 || Peek(h) != 1 {
+		t.Errorf("Len(%v), Peek(%v) = %v, %v, want = 5, 1", h, h, Len(h), Peek(h))
+	}
+	var got []int
+	for Len(h) > 0 {
+		got = append(got, Pop(h))
+	}
+	if want := []int{1, 2, 3, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+}
+
+func TestFromIter(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := r.Perm(200)
+	h := FromIter[int](goslices.Iter(s), gcl.Greater[int])
+	got := goslices.FromIter[int](Drain(h))
+	want := slices.Clone(s)
+	slices.SortFunc(want, gcl.Greater[int])
+	if !slices.Equal(got, want) {
+		t.Errorf("Drain yielded %v, want %v", got, want)
+	}
+	if Len(h) != 0 {
+		t.Errorf("Len(h) = %v after Drain, want = 0", Len(h))
+	}
+}
+
+func TestUpdateRemove(t *testing.T) {
+	h := New(gcl.Less[int], 10, 20, 30)
+	a := Push(h, 40)
+	b := Push(h, 50)
+	c := Push(h, 60)
+
+	Update(h, b, 5)
+	if Peek(h) != 5 || b.Value() != 5 {
+		t.Errorf("Peek(%v) = %v after decrease-key, want = 5", h, Peek(h))
+	}
+	Update(h, b, 55)
+	if Peek(h) != 10 {
+		t.Errorf("Peek(%v) = %v after increase-key, want = 10", h, Peek(h))
+	}
+	if v := Remove(h, a); v != 40 {
+		t.Errorf("Remove(h, a) = %v, want = %v", v, 40)
+	}
+	if v := Remove(h, c); v != 60 {
+		t.Errorf("Remove(h, c) = %v, want = %v", v, 60)
+	}
+	if got, want := goslices.FromIter[int](Drain(h)), []int{10, 20, 30, 55}; !slices.Equal(got, want) {
+		t.Errorf("Drain yielded %v, want %v", got, want)
+	}
+}
+
+func TestRandomizedHandles(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := New(gcl.Less[int])
+	var handles []*Handle[int]
+	for i := 0; i < 2000; i++ {
+		switch op := r.Intn(4); {
+		case op == 0 && len(handles) > 0:
+			j := r.Intn(len(handles))
+			Remove(h, handles[j])
+			handles = append(handles[:j], handles[j+1:]...)
+		case op == 1 && len(handles) > 0:
+			Update(h, handles[r.Intn(len(handles))], r.Intn(1000))
+		default:
+			handles = append(handles, Push(h, r.Intn(1000)))
+		}
+	}
+	var want []int
+	for _, handle := range handles {
+		want = append(want, handle.Value())
+	}
+	slices.Sort(want)
+	if got := goslices.FromIter[int](Drain(h)); !slices.Equal(got, want) {
+		t.Errorf("Drain yielded %v, want %v", got, want)
+	}
+}
+
+func TestForeignHandle(t *testing.T) {
+	h1 := New(gcl.Less[int])
+	h2 := New(gcl.Less[int])
+	handle := Push(h1, 1)
+	for _, fn := range []func(){
+		func() { Remove(h2, handle) },
+		func() { Update(h2, handle, 2) },
+		func() {
+			Pop(h1)
+			Remove(h1, handle)
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("using a handle of another heap must panic")
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestClone(t *testing.T) {
+	h := New(gcl.Less[int], 3, 1, 2)
+	cloned := Clone(h)
+	Pop(cloned)
+	if Len(h) != 3 || Peek(h) != 1 || Peek(cloned) != 2 {
+		t.Error("modifying a clone must not modify the original heap")
+	}
+}
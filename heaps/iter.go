@@ -0,0 +1,22 @@
+package heaps
+
+func require(check bool, failMsg string) {
+	if !check {
+		panic(failMsg)
+	}
+}
+
+// DrainIter is an iterator that pops the elements of a heap in priority
+// order.
+type DrainIter[T any] struct {
+	heap *Heap[T]
+}
+
+func (it *DrainIter[T]) HasNext() bool {
+	return Len(it.heap) > 0
+}
+
+func (it *DrainIter[T]) Next() T {
+	require(it.HasNext(), "iterator must have next")
+	return Pop(it.heap)
+}
@@ -0,0 +1,251 @@
+// Package hmaps provides an unordered hash map with user-supplied hash and
+// equal functions. Unlike built-in maps, keys do not need to be comparable,
+// and the iteration order is deterministic: elements are visited in the order
+// their keys were first inserted.
+//
+// A map is not safe for concurrent use. Package syncs provides a map that is
+// guarded by a lock.
+package hmaps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/iters"
+)
+
+const (
+	minCapacity = 8
+
+	// The index table is grown when more than maxLoadNum/maxLoadDen of its
+	// slots are in use.
+	maxLoadNum = 3
+	maxLoadDen = 4
+)
+
+type entry[K any, V any] struct {
+	key     K
+	value   V
+	hash    uint64
+	deleted bool
+}
+
+// Map is a hash map. Elements are stored in insertion order in a dense
+// slice, and an open addressing table maps hashes to their positions in that
+// slice.
+type Map[K any, V any] struct {
+	hash gcl.HashFn[K]
+	eq   gcl.EqualFn[K, K]
+
+	entries []entry[K, V]
+	// index holds positions in entries, or -1 for empty slots. Its length is
+	// always a power of two.
+	index []int
+	size  int
+}
+
+// New creates a new empty map that uses the given hash and equal functions
+// for keys and returns a pointer to it. Keys that are equal according to `eq`
+// must have the same hash.
+func New[K any, V any](hash gcl.HashFn[K], eq gcl.EqualFn[K, K]) *Map[K, V] {
+	return &Map[K, V]{
+		hash:  hash,
+		eq:    eq,
+		index: newIndex(minCapacity),
+	}
+}
+
+// FromIter builds a new map from the given iterator, using the given hash and
+// equal functions for keys. For duplicate keys, the last value wins.
+func FromIter[K any, V any](it iters.Iterator[gcl.MapElem[K, V]], hash gcl.HashFn[K], eq gcl.EqualFn[K, K]) *Map[K, V] {
+	m := New[K, V](hash, eq)
+	for it.HasNext() {
+		elem := it.Next()
+		Put(m, elem.Key, elem.Value)
+	}
+	return m
+}
+
+func (m *Map[K, V]) String() string {
+	var b strings.Builder
+	b.WriteString("hmaps.Map[")
+	it := Iter(m)
+	for it.HasNext() {
+		elem := it.Next()
+		fmt.Fprintf(&b, "%v:%v", elem.Key, elem.Value)
+		if it.HasNext() {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Len returns the number of elements in the given map. This function is O(1).
+func Len[K any, V any](m *Map[K, V]) int {
+	return m.size
+}
+
+// Get returns the value of key k. The returned boolean value indicates if k
+// is present in the map.
+// This function is O(1) on average.
+func Get[K any, V any](m *Map[K, V], k K) (v V, ok bool) {
+	if _, pos := m.find(k, m.hash(k)); pos >= 0 {
+		return m.entries[pos].value, true
+	}
+	return
+}
+
+// Contains tests whether the given map m has key k.
+// This function is O(1) on average.
+func Contains[K any, V any](m *Map[K, V], k K) bool {
+	_, pos := m.find(k, m.hash(k))
+	return pos >= 0
+}
+
+// Put sets the value of key k to v. If k is already present, its value is
+// replaced and its position in the iteration order is kept.
+// This function is O(1) amortized.
+func Put[K any, V any](m *Map[K, V], k K, v V) {
+	h := m.hash(k)
+	slot, pos := m.find(k, h)
+	if pos >= 0 {
+		m.entries[pos].value = v
+		return
+	}
+	m.insert(slot, k, v, h)
+}
+
+// GetOrInsert returns the value of key k if it is present. Otherwise, it
+// inserts k with value v and returns v. The returned boolean value is true if
+// the value was already present and false if it was inserted.
+// This function is O(1) amortized.
+func GetOrInsert[K any, V any](m *Map[K, V], k K, v V) (V, bool) {
+	h := m.hash(k)
+	slot, pos := m.find(k, h)
+	if pos >= 0 {
+		return m.entries[pos].value, true
+	}
+	m.insert(slot, k, v, h)
+	return v, false
+}
+
+// Update replaces the value of key k with fn(value). The returned boolean
+// value indicates if k is present in the map. If k is not present, fn is not
+// called and the map is not modified.
+// This function is O(f) on average, where f is time complexity of `fn`.
+func Update[K any, V any](m *Map[K, V], k K, fn func(V) V) bool {
+	_, pos := m.find(k, m.hash(k))
+	if pos < 0 {
+		return false
+	}
+	m.entries[pos].value = fn(m.entries[pos].value)
+	return true
+}
+
+// Delete deletes key k from the map. The returned boolean value indicates if
+// k was present in the map.
+// This function is O(1) on average.
+func Delete[K any, V any](m *Map[K, V], k K) bool {
+	_, pos := m.find(k, m.hash(k))
+	if pos < 0 {
+		return false
+	}
+	// The index slot keeps pointing at the deleted entry and acts as a
+	// tombstone until the next rehash. The entry is cleared so that it does
+	// not keep its key and value alive.
+	m.entries[pos] = entry[K, V]{deleted: true}
+	m.size -= 1
+	return true
+}
+
+// Iter returns an iterator over the elements of the given map. Elements are
+// visited in the order their keys were first inserted.
+// Any insertion or deletion in the map invalidates the iterator.
+func Iter[K any, V any](m *Map[K, V]) *Iterator[K, V] {
+	it := &Iterator[K, V]{
+		entries: m.entries,
+		index:   -1,
+	}
+	it.advance()
+	return it
+}
+
+// Clone returns a copy of the given map. Keys and values are copied using
+// assignment, so this is a shallow clone.
+// This function is O(n), where n is size of the map.
+func Clone[K any, V any](m *Map[K, V]) *Map[K, V] {
+	res := &Map[K, V]{
+		hash:    m.hash,
+		eq:      m.eq,
+		entries: make([]entry[K, V], len(m.entries)),
+		index:   make([]int, len(m.index)),
+		size:    m.size,
+	}
+	copy(res.entries, m.entries)
+	copy(res.index, m.index)
+	return res
+}
+
+func newIndex(capacity int) []int {
+	index := make([]int, capacity)
+	for i := range index {
+		index[i] = -1
+	}
+	return index
+}
+
+// find looks up key k with hash h. It returns the index slot and the position
+// of its entry. If k is not present, the position is -1 and the slot is the
+// empty slot where k should be inserted.
+func (m *Map[K, V]) find(k K, h uint64) (int, int) {
+	mask := uint64(len(m.index) - 1)
+	for i := h & mask; ; i = (i + 1) & mask {
+		pos := m.index[i]
+		if pos < 0 {
+			return int(i), -1
+		}
+		e := &m.entries[pos]
+		if !e.deleted && e.hash == h && m.eq(e.key, k) {
+			return int(i), pos
+		}
+	}
+}
+
+func (m *Map[K, V]) insert(slot int, k K, v V, h uint64) {
+	if (len(m.entries)+1)*maxLoadDen > len(m.index)*maxLoadNum {
+		m.rehash()
+		slot, _ = m.find(k, h)
+	}
+	m.index[slot] = len(m.entries)
+	m.entries = append(m.entries, entry[K, V]{key: k, value: v, hash: h})
+	m.size += 1
+}
+
+// rehash drops deleted entries and rebuilds the index table. The table is
+// grown if the live entries alone would exceed the maximum load factor.
+func (m *Map[K, V]) rehash() {
+	capacity := len(m.index)
+	for (m.size+1)*maxLoadDen > capacity*maxLoadNum {
+		capacity *= 2
+	}
+
+	entries := make([]entry[K, V], 0, capacity*maxLoadNum/maxLoadDen)
+	for _, e := range m.entries {
+		if !e.deleted {
+			entries = append(entries, e)
+		}
+	}
+	m.entries = entries
+
+	m.index = newIndex(capacity)
+	mask := uint64(capacity - 1)
+	for pos, e := range m.entries {
+		i := e.hash & mask
+		for m.index[i] >= 0 {
+			i = (i + 1) & mask
+		}
+		m.index[i] = pos
+	}
+}
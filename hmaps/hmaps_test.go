@@ -0,0 +1,168 @@
+package hmaps
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+	"golang.org/x/exp/slices"
+)
+
+func hashInt(v int) uint64 {
+	return uint64(v)
+}
+
+func hashFold(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(strings.ToLower(s)))
+	return h.Sum64()
+}
+
+func hashInts(s []int) uint64 {
+	h := fnv.New64a()
+	for _, v := range s {
+		h.Write([]byte{byte(v), byte(v >> 8), byte(v >> 16), byte(v >> 24)})
+	}
+	return h.Sum64()
+}
+
+func keys[K any, V any](m *Map[K, V]) []K {
+	var res []K
+	it := Iter(m)
+	for it.HasNext() {
+		res = append(res, it.Next().Key)
+	}
+	return res
+}
+
+func TestPutGetDelete(t *testing.T) {
+	m := New[string, int](hashFold, strings.EqualFold)
+	Put(m, "a", 1)
+	Put(m, "B", 2)
+	Put(m, "A", 3)
+
+	if Len(m) != 2 {
+		t.Errorf("Len(%v) = %v, want = %v", m, Len(m), 2)
+	}
+	if v, ok := Get(m, "a"); !ok || v != 3 {
+		t.Errorf("Get(%v, a) = (%v, %v), want = (3, true)", m, v, ok)
+	}
+	if !Contains(m, "b") || Contains(m, "c") {
+		t.Errorf("wrong Contains result for %v", m)
+	}
+	if !Delete(m, "b") || Delete(m, "b") {
+		t.Errorf("wrong Delete result for %v", m)
+	}
+	if got := keys(m); !slices.Equal(got, []string{"a"}) {
+		t.Errorf("got keys %v, want %v", got, []string{"a"})
+	}
+}
+
+func TestSliceKeys(t *testing.T) {
+	m := New[[]int, string](hashInts, slices.Equal[int])
+	Put(m, []int{1, 2}, "a")
+	Put(m, []int{2, 1}, "b")
+	if v, _ := Get(m, []int{1, 2}); v != "a" {
+		t.Errorf("Get(%v, [1 2]) = %v, want = %v", m, v, "a")
+	}
+	if v, _ := Get(m, []int{2, 1}); v != "b" {
+		t.Errorf("Get(%v, [2 1]) = %v, want = %v", m, v, "b")
+	}
+}
+
+func TestGetOrInsertUpdate(t *testing.T) {
+	m := New[int, int](hashInt, gcl.Equal[int])
+	if v, ok := GetOrInsert(m, 1, 10); ok || v != 10 {
+		t.Errorf("GetOrInsert(m, 1, 10) = (%v, %v), want = (10, false)", v, ok)
+	}
+	if v, ok := GetOrInsert(m, 1, 20); !ok || v != 10 {
+		t.Errorf("GetOrInsert(m, 1, 20) = (%v, %v), want = (10, true)", v, ok)
+	}
+	inc := func(v int) int { return v + 1 }
+	if !Update(m, 1, inc) {
+		t.Errorf("Update(m, 1, inc) = false, want = true")
+	}
+	if Update(m, 2, inc) {
+		t.Errorf("Update(m, 2, inc) = true, want = false")
+	}
+	if v, _ := Get(m, 1); v != 11 || Len(m) != 1 {
+		t.Errorf("got %v, want hmaps.Map[1:11]", m)
+	}
+}
+
+func TestIterationOrder(t *testing.T) {
+	m := New[int, int](hashInt, gcl.Equal[int])
+	for _, k := range []int{5, 3, 9, 1, 7} {
+		Put(m, k, k)
+	}
+	Put(m, 3, 30)
+	Delete(m, 9)
+	Put(m, 9, 90)
+	if got, want := keys(m), []int{5, 3, 1, 7, 9}; !slices.Equal(got, want) {
+		t.Errorf("got keys %v, want %v", got, want)
+	}
+
+	// Growing and compacting the map must preserve insertion order.
+	var want []int
+	for i := 0; i < 1000; i++ {
+		Put(m, 100+i, i)
+		if i%3 == 0 {
+			Delete(m, 100+i)
+		} else {
+			want = append(want, 100+i)
+		}
+	}
+	if got := keys(m)[5:]; !slices.Equal(got, want) {
+		t.Errorf("insertion order is not preserved after growth")
+	}
+}
+
+func TestRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	// A poor hash function stresses probing.
+	m := New[int, int](func(v int) uint64 { return uint64(v % 7) }, gcl.Equal[int])
+	ref := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		k := r.Intn(300)
+		switch r.Intn(3) {
+		case 0:
+			_, want := ref[k]
+			if got := Delete(m, k); got != want {
+				t.Fatalf("Delete(m, %v) = %v, want = %v", k, got, want)
+			}
+			delete(ref, k)
+		default:
+			Put(m, k, i)
+			ref[k] = i
+		}
+	}
+	if Len(m) != len(ref) {
+		t.Fatalf("Len(m) = %v, want = %v", Len(m), len(ref))
+	}
+	for k, v := range ref {
+		if got, ok := Get(m, k); !ok || got != v {
+			t.Fatalf("Get(m, %v) = (%v, %v), want = (%v, true)", k, got, ok, v)
+		}
+	}
+}
+
+func TestFromIterClone(t *testing.T) {
+	elems := []gcl.MapElem[int, string]{
+		{Key: 2, Value: "b"},
+		{Key: 1, Value: "a"},
+	}
+	m := FromIter[int, string](goslices.Iter(elems), hashInt, gcl.Equal[int])
+	if !iters.Equal[gcl.MapElem[int, string]](Iter(m), goslices.Iter(elems)) {
+		t.Error("Wrong FromIter result")
+	}
+	cloned := Clone(m)
+	Put(cloned, 3, "c")
+	Delete(cloned, 2)
+	if Len(m) != 2 || !Contains(m, 2) || Contains(m, 3) {
+		t.Error("modifying a clone must not modify the original map")
+	}
+}
@@ -0,0 +1,37 @@
+package hmaps
+
+import (
+	"github.com/shayanh/gcl"
+)
+
+// Iterator is an iterator over the elements of a hash map. It yields the
+// elements in insertion order.
+type Iterator[K any, V any] struct {
+	entries []entry[K, V]
+	// index is the position of the next live entry, or len(entries) if there
+	// is none.
+	index int
+}
+
+func (it *Iterator[K, V]) advance() {
+	it.index += 1
+	for it.index < len(it.entries) && it.entries[it.index].deleted {
+		it.index += 1
+	}
+}
+
+func (it *Iterator[K, V]) HasNext() bool {
+	return it.index < len(it.entries)
+}
+
+func (it *Iterator[K, V]) Next() gcl.MapElem[K, V] {
+	if !it.HasNext() {
+		panic("iterator must have next")
+	}
+	e := &it.entries[it.index]
+	it.advance()
+	return gcl.MapElem[K, V]{
+		Key:   e.key,
+		Value: e.value,
+	}
+}
@@ -0,0 +1,217 @@
+// Package hsets provides an unordered hash set and various functions useful
+// with sets of any comparable type.
+//
+// A set is not safe for concurrent use without external synchronization.
+package hsets
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shayanh/gcl/iters"
+)
+
+// Set is an unordered hash set.
+type Set[T comparable] struct {
+	m map[T]struct{}
+}
+
+// New creates a new set containing the given elements and returns a pointer
+// to it. Duplicate elements are stored once.
+func New[T comparable](elems ...T) *Set[T] {
+	s := &Set[T]{
+		m: make(map[T]struct{}, len(elems)),
+	}
+	Add(s, elems...)
+	return s
+}
+
+// FromIter builds a new set from the given iterator.
+func FromIter[T comparable](it iters.Iterator[T]) *Set[T] {
+	s := New[T]()
+	for it.HasNext() {
+		Add(s, it.Next())
+	}
+	return s
+}
+
+func (s *Set[T]) String() string {
+	var b strings.Builder
+	b.WriteString("hsets.Set[")
+	it := Iter(s)
+	for it.HasNext() {
+		v := it.Next()
+		fmt.Fprintf(&b, "%v", v)
+		if it.HasNext() {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toSlice(s))
+}
+
+func (s *Set[T]) UnmarshalJSON(b []byte) error {
+	var slice []T
+	if err := json.Unmarshal(b, &slice); err != nil {
+		return err
+	}
+	*s = *New(slice...)
+	return nil
+}
+
+// Len returns the number of elements in the given set. This function is O(1).
+func Len[T comparable](s *Set[T]) int {
+	return len(s.m)
+}
+
+// Iter returns an iterator over the elements of the given set. The iteration
+// order is not specified and is not guaranteed to be the same from one
+// iteration to the next.
+func Iter[T comparable](s *Set[T]) *Iterator[T] {
+	return newIterator(s.m)
+}
+
+// Add adds the given elements to set s. Elements that are already present are
+// ignored.
+// This function is O(len(elems)). So for a single element it would be O(1).
+func Add[T comparable](s *Set[T], elems ...T) {
+	for _, elem := range elems {
+		s.m[elem] = struct{}{}
+	}
+}
+
+// Remove removes the given elements from set s. Elements that are not present
+// are ignored.
+// This function is O(len(elems)). So for a single element it would be O(1).
+func Remove[T comparable](s *Set[T], elems ...T) {
+	for _, elem := range elems {
+		delete(s.m, elem)
+	}
+}
+
+// Contains tests whether the given set s has value v.
+// This function is O(1).
+func Contains[T comparable](s *Set[T], v T) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+// Clone returns a copy of the given set. The elements are copied using
+// assignment, so this is a shallow clone.
+// This function is O(n), where n is size of the set.
+func Clone[T comparable](s *Set[T]) *Set[T] {
+	res := &Set[T]{
+		m: make(map[T]struct{}, len(s.m)),
+	}
+	for v := range s.m {
+		res.m[v] = struct{}{}
+	}
+	return res
+}
+
+// Equal tests whether two sets contain the same elements.
+// This function is O(min(Len(s1), Len(s2))).
+func Equal[T comparable](s1, s2 *Set[T]) bool {
+	if len(s1.m) != len(s2.m) {
+		return false
+	}
+	for v := range s1.m {
+		if _, ok := s2.m[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new set containing the elements that are in s1, s2 or both.
+// This function is O(Len(s1) + Len(s2)).
+func Union[T comparable](s1, s2 *Set[T]) *Set[T] {
+	res := Clone(s1)
+	for v := range s2.m {
+		res.m[v] = struct{}{}
+	}
+	return res
+}
+
+// Intersection returns a new set containing the elements that are in both s1
+// and s2.
+// This function is O(min(Len(s1), Len(s2))).
+func Intersection[T comparable](s1, s2 *Set[T]) *Set[T] {
+	if len(s1.m) > len(s2.m) {
+		s1, s2 = s2, s1
+	}
+	res := New[T]()
+	for v := range s1.m {
+		if _, ok := s2.m[v]; ok {
+			res.m[v] = struct{}{}
+		}
+	}
+	return res
+}
+
+// Difference returns a new set containing the elements of s1 that are not in
+// s2.
+// This function is O(Len(s1)).
+func Difference[T comparable](s1, s2 *Set[T]) *Set[T] {
+	res := New[T]()
+	for v := range s1.m {
+		if _, ok := s2.m[v]; !ok {
+			res.m[v] = struct{}{}
+		}
+	}
+	return res
+}
+
+// SymmetricDifference returns a new set containing the elements that are in
+// exactly one of s1 and s2.
+// This function is O(Len(s1) + Len(s2)).
+func SymmetricDifference[T comparable](s1, s2 *Set[T]) *Set[T] {
+	res := Difference(s1, s2)
+	for v := range s2.m {
+		if _, ok := s1.m[v]; !ok {
+			res.m[v] = struct{}{}
+		}
+	}
+	return res
+}
+
+// IsSubset tests whether every element of s1 is also in s2.
+// This function is O(Len(s1)).
+func IsSubset[T comparable](s1, s2 *Set[T]) bool {
+	if len(s1.m) > len(s2.m) {
+		return false
+	}
+	for v := range s1.m {
+		if _, ok := s2.m[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsDisjoint tests whether s1 and s2 have no elements in common.
+// This function is O(min(Len(s1), Len(s2))).
+func IsDisjoint[T comparable](s1, s2 *Set[T]) bool {
+	if len(s1.m) > len(s2.m) {
+		s1, s2 = s2, s1
+	}
+	for v := range s1.m {
+		if _, ok := s2.m[v]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+func toSlice[T comparable](s *Set[T]) []T {
+	res := make([]T, 0, len(s.m))
+	for v := range s.m {
+		res = append(res, v)
+	}
+	return res
+}
@@ -0,0 +1,192 @@
+package hsets
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shayanh/gcl/goslices"
+	"golang.org/x/exp/slices"
+)
+
+func TestNew(t *testing.T) {
+	s := New(1, 2, 2, 3, 1)
+	if Len(s) != 3 {
+		t.Errorf("Len(%v) = %v, want = %v", s, Len(s), 3)
+	}
+	for _, v := range []int{1, 2, 3} {
+		if !Contains(s, v) {
+			t.Errorf("Contains(%v, %v) = false, want = true", s, v)
+		}
+	}
+	if Contains(s, 4) {
+		t.Errorf("Contains(%v, %v) = true, want = false", s, 4)
+	}
+}
+
+func TestFromIter(t *testing.T) {
+	s := FromIter[int](goslices.Iter([]int{3, 1, 3, 2}))
+	if want := New(1, 2, 3); !Equal(s, want) {
+		t.Errorf("FromIter got %v, want %v", s, want)
+	}
+}
+
+func TestIter(t *testing.T) {
+	s := New(1, 2, 3, 4)
+	var got []int
+	it := Iter(s)
+	for it.HasNext() {
+		got = append(got, it.Next())
+	}
+	slices.Sort(got)
+	if want := []int{1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("Iter(%v) yielded %v, want %v", s, got, want)
+	}
+
+	if it := Iter(New[int]()); it.HasNext() {
+		t.Error("Iter of an empty set must not have next")
+	}
+}
+
+func TestAddRemove(t *testing.T) {
+	s := New[string]()
+	Add(s, "a", "b", "c")
+	Remove(s, "b", "d")
+	if want := New("a", "c"); !Equal(s, want) {
+		t.Errorf("got %v, want %v", s, want)
+	}
+}
+
+func TestClone(t *testing.T) {
+	s := New(1, 2, 3)
+	cloned := Clone(s)
+	Add(cloned, 4)
+	if Contains(s, 4) {
+		t.Error("modifying a clone must not modify the original set")
+	}
+	if want := New(1, 2, 3, 4); !Equal(cloned, want) {
+		t.Errorf("got %v, want %v", cloned, want)
+	}
+}
+
+var equalTests = []struct {
+	s1, s2 *Set[int]
+	want   bool
+}{
+	{
+		New[int](),
+		New[int](),
+		true,
+	},
+	{
+		New(1, 2, 3),
+		New(3, 2, 1),
+		true,
+	},
+	{
+		New(1, 2, 3),
+		New(1, 2, 4),
+		false,
+	},
+	{
+		New(1, 2, 3),
+		New(1, 2, 3, 4),
+		false,
+	},
+}
+
+func TestEqual(t *testing.T) {
+	for _, test := range equalTests {
+		if res := Equal(test.s1, test.s2); res != test.want {
+			t.Errorf("Equal(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.want)
+		}
+	}
+}
+
+var algebraTests = []struct {
+	s1, s2                  *Set[int]
+	union, inter, diff, sym *Set[int]
+	subset, disjoint        bool
+}{
+	{
+		s1:       New[int](),
+		s2:       New[int](),
+		union:    New[int](),
+		inter:    New[int](),
+		diff:     New[int](),
+		sym:      New[int](),
+		subset:   true,
+		disjoint: true,
+	},
+	{
+		s1:       New(1, 2, 3),
+		s2:       New(2, 3, 4),
+		union:    New(1, 2, 3, 4),
+		inter:    New(2, 3),
+		diff:     New(1),
+		sym:      New(1, 4),
+		subset:   false,
+		disjoint: false,
+	},
+	{
+		s1:       New(1, 2),
+		s2:       New(1, 2, 3),
+		union:    New(1, 2, 3),
+		inter:    New(1, 2),
+		diff:     New[int](),
+		sym:      New(3),
+		subset:   true,
+		disjoint: false,
+	},
+	{
+		s1:       New(1, 2),
+		s2:       New(3, 4),
+		union:    New(1, 2, 3, 4),
+		inter:    New[int](),
+		diff:     New(1, 2),
+		sym:      New(1, 2, 3, 4),
+		subset:   false,
+		disjoint: true,
+	},
+}
+
+func TestAlgebra(t *testing.T) {
+	for _, test := range algebraTests {
+		if res := Union(test.s1, test.s2); !Equal(res, test.union) {
+			t.Errorf("Union(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.union)
+		}
+		if res := Intersection(test.s1, test.s2); !Equal(res, test.inter) {
+			t.Errorf("Intersection(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.inter)
+		}
+		if res := Difference(test.s1, test.s2); !Equal(res, test.diff) {
+			t.Errorf("Difference(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.diff)
+		}
+		if res := SymmetricDifference(test.s1, test.s2); !Equal(res, test.sym) {
+			t.Errorf("SymmetricDifference(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.sym)
+		}
+		if res := IsSubset(test.s1, test.s2); res != test.subset {
+			t.Errorf("IsSubset(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.subset)
+		}
+		if res := IsDisjoint(test.s1, test.s2); res != test.disjoint {
+			t.Errorf("IsDisjoint(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.disjoint)
+		}
+	}
+}
+
+func TestJSONSerialization(t *testing.T) {
+	s1 := New(1, 2, 3)
+
+	m, err := json.Marshal(s1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s2 *Set[int]
+	err = json.Unmarshal(m, &s2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !Equal(s1, s2) {
+		t.Error("Wrong JSON serialization")
+	}
+}
@@ -0,0 +1,32 @@
+package hsets
+
+import (
+	"reflect"
+)
+
+// Iterator is an iterator over the elements of a hash set.
+type Iterator[T comparable] struct {
+	impl    *reflect.MapIter
+	hasNext bool
+}
+
+func newIterator[T comparable](m map[T]struct{}) *Iterator[T] {
+	impl := reflect.ValueOf(m).MapRange()
+	return &Iterator[T]{
+		impl:    impl,
+		hasNext: impl.Next(),
+	}
+}
+
+func (it *Iterator[T]) HasNext() bool {
+	return it.hasNext
+}
+
+func (it *Iterator[T]) Next() T {
+	if !it.hasNext {
+		panic("iterator must have next")
+	}
+	v := it.impl.Key().Interface().(T)
+	it.hasNext = it.impl.Next()
+	return v
+}
@@ -0,0 +1,345 @@
+// Package avl implements an AVL tree, a self-balancing binary search tree,
+// which is shared by the ordered containers.
+package avl
+
+import (
+	"github.com/shayanh/gcl"
+)
+
+// Node is a node of the tree.
+type Node[K any, V any] struct {
+	Key   K
+	Value V
+
+	left   *Node[K, V]
+	right  *Node[K, V]
+	height int
+	// size is the number of nodes in the subtree rooted at this node.
+	size int
+}
+
+// Tree is an AVL tree ordered by its compare function.
+type Tree[K any, V any] struct {
+	root *Node[K, V]
+	size int
+	cmp  gcl.CompareFn[K, K]
+}
+
+// New creates an empty tree ordered by cmp.
+func New[K any, V any](cmp gcl.CompareFn[K, K]) *Tree[K, V] {
+	return &Tree[K, V]{cmp: cmp}
+}
+
+// Len returns the number of nodes in the tree.
+func (t *Tree[K, V]) Len() int {
+	return t.size
+}
+
+// FromSorted builds a balanced tree from the given keys and values. keys must
+// be sorted in strictly ascending order according to cmp. values can be nil,
+// in which case all values are zero. FromSorted is O(len(keys)).
+func FromSorted[K any, V any](cmp gcl.CompareFn[K, K], keys []K, values []V) *Tree[K, V] {
+	return &Tree[K, V]{
+		root: build(keys, values, 0, len(keys)),
+		size: len(keys),
+		cmp:  cmp,
+	}
+}
+
+func build[K any, V any](keys []K, values []V, lo, hi int) *Node[K, V] {
+	if lo >= hi {
+		return nil
+	}
+	mid := lo + (hi-lo)/2
+	n := &Node[K, V]{Key: keys[mid]}
+	if values != nil {
+		n.Value = values[mid]
+	}
+	n.left = build(keys, values, lo, mid)
+	n.right = build(keys, values, mid+1, hi)
+	n.update()
+	return n
+}
+
+// Cmp returns the compare function of the tree.
+func (t *Tree[K, V]) Cmp() gcl.CompareFn[K, K] {
+	return t.cmp
+}
+
+// Find returns the node with key k or nil if there is no such node.
+func (t *Tree[K, V]) Find(k K) *Node[K, V] {
+	n := t.root
+	for n != nil {
+		c := t.cmp(k, n.Key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Put sets the value of key k to v. It returns true if a new node has been
+// inserted and false if an existing node has been updated.
+func (t *Tree[K, V]) Put(k K, v V) bool {
+	size := t.size
+	t.root = t.insert(t.root, k, v)
+	return t.size > size
+}
+
+// Delete deletes the node with key k. It returns false if there is no such
+// node.
+func (t *Tree[K, V]) Delete(k K) bool {
+	var ok bool
+	t.root, ok = t.delete(t.root, k)
+	if ok {
+		t.size -= 1
+	}
+	return ok
+}
+
+// Min returns the node with the smallest key or nil if the tree is empty.
+func (t *Tree[K, V]) Min() *Node[K, V] {
+	n := t.root
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// Max returns the node with the largest key or nil if the tree is empty.
+func (t *Tree[K, V]) Max() *Node[K, V] {
+	n := t.root
+	if n == nil {
+		return nil
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// Rank returns the number of nodes with keys less than k.
+func (t *Tree[K, V]) Rank(k K) int {
+	rank := 0
+	n := t.root
+	for n != nil {
+		if t.cmp(k, n.Key) <= 0 {
+			n = n.left
+		} else {
+			rank += size(n.left) + 1
+			n = n.right
+		}
+	}
+	return rank
+}
+
+// Select returns the node with the i-th smallest key, counting from zero. It
+// returns nil if i is out of range.
+func (t *Tree[K, V]) Select(i int) *Node[K, V] {
+	if i < 0 || i >= t.size {
+		return nil
+	}
+	n := t.root
+	for n != nil {
+		ls := size(n.left)
+		switch {
+		case i < ls:
+			n = n.left
+		case i > ls:
+			i -= ls + 1
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Floor returns the node with the largest key less than or equal to k or nil
+// if there is no such node.
+func (t *Tree[K, V]) Floor(k K) *Node[K, V] {
+	var res *Node[K, V]
+	n := t.root
+	for n != nil {
+		c := t.cmp(k, n.Key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			res = n
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return res
+}
+
+// Ceiling returns the node with the smallest key greater than or equal to k
+// or nil if there is no such node.
+func (t *Tree[K, V]) Ceiling(k K) *Node[K, V] {
+	var res *Node[K, V]
+	n := t.root
+	for n != nil {
+		c := t.cmp(k, n.Key)
+		switch {
+		case c < 0:
+			res = n
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return res
+}
+
+// Clone returns a copy of the tree. Keys and values are copied using
+// assignment.
+func (t *Tree[K, V]) Clone() *Tree[K, V] {
+	return &Tree[K, V]{
+		root: clone(t.root),
+		size: t.size,
+		cmp:  t.cmp,
+	}
+}
+
+func clone[K any, V any](n *Node[K, V]) *Node[K, V] {
+	if n == nil {
+		return nil
+	}
+	return &Node[K, V]{
+		Key:    n.Key,
+		Value:  n.Value,
+		left:   clone(n.left),
+		right:  clone(n.right),
+		height: n.height,
+		size:   n.size,
+	}
+}
+
+func (t *Tree[K, V]) insert(n *Node[K, V], k K, v V) *Node[K, V] {
+	if n == nil {
+		t.size += 1
+		return &Node[K, V]{Key: k, Value: v, height: 1, size: 1}
+	}
+	c := t.cmp(k, n.Key)
+	switch {
+	case c < 0:
+		n.left = t.insert(n.left, k, v)
+	case c > 0:
+		n.right = t.insert(n.right, k, v)
+	default:
+		n.Value = v
+		return n
+	}
+	return rebalance(n)
+}
+
+func (t *Tree[K, V]) delete(n *Node[K, V], k K) (*Node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var ok bool
+	c := t.cmp(k, n.Key)
+	switch {
+	case c < 0:
+		n.left, ok = t.delete(n.left, k)
+	case c > 0:
+		n.right, ok = t.delete(n.right, k)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// Replace n with its successor. The successor node is relinked
+		// instead of copied, so nodes keep their key and value.
+		var succ *Node[K, V]
+		n.right, succ = deleteMin(n.right)
+		succ.left, succ.right = n.left, n.right
+		n, ok = succ, true
+	}
+	if !ok {
+		return n, false
+	}
+	return rebalance(n), true
+}
+
+func deleteMin[K any, V any](n *Node[K, V]) (*Node[K, V], *Node[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var min *Node[K, V]
+	n.left, min = deleteMin(n.left)
+	return rebalance(n), min
+}
+
+func height[K any, V any](n *Node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func size[K any, V any](n *Node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *Node[K, V]) update() {
+	n.size = size(n.left) + size(n.right) + 1
+	hl, hr := height(n.left), height(n.right)
+	if hl > hr {
+		n.height = hl + 1
+	} else {
+		n.height = hr + 1
+	}
+}
+
+func rotateLeft[K any, V any](n *Node[K, V]) *Node[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func rotateRight[K any, V any](n *Node[K, V]) *Node[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+func rebalance[K any, V any](n *Node[K, V]) *Node[K, V] {
+	n.update()
+	switch bf := height(n.left) - height(n.right); {
+	case bf > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case bf < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
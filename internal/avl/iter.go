@@ -0,0 +1,97 @@
+package avl
+
+import (
+	"github.com/shayanh/gcl"
+)
+
+// Iterator is an in-order iterator over the nodes of a tree. It keeps the
+// path to the next node on a stack, so it is invalidated by any insertion or
+// deletion in the tree.
+type Iterator[K any, V any] struct {
+	stack   []*Node[K, V]
+	reverse bool
+
+	// If bounded is true, iteration stops at the first key that is not less
+	// than hi.
+	bounded bool
+	hi      K
+	cmp     gcl.CompareFn[K, K]
+}
+
+// Iter returns an iterator that visits the nodes in ascending key order.
+func (t *Tree[K, V]) Iter() *Iterator[K, V] {
+	it := &Iterator[K, V]{}
+	it.pushLeft(t.root)
+	return it
+}
+
+// RIter returns an iterator that visits the nodes in descending key order.
+func (t *Tree[K, V]) RIter() *Iterator[K, V] {
+	it := &Iterator[K, V]{reverse: true}
+	it.pushRight(t.root)
+	return it
+}
+
+// Range returns an iterator that visits the nodes with keys in [lo, hi) in
+// ascending key order.
+func (t *Tree[K, V]) Range(lo, hi K) *Iterator[K, V] {
+	it := &Iterator[K, V]{
+		bounded: true,
+		hi:      hi,
+		cmp:     t.cmp,
+	}
+	n := t.root
+	for n != nil {
+		if t.cmp(n.Key, lo) >= 0 {
+			it.stack = append(it.stack, n)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return it
+}
+
+func (it *Iterator[K, V]) pushLeft(n *Node[K, V]) {
+	for ; n != nil; n = n.left {
+		it.stack = append(it.stack, n)
+	}
+}
+
+func (it *Iterator[K, V]) pushRight(n *Node[K, V]) {
+	for ; n != nil; n = n.right {
+		it.stack = append(it.stack, n)
+	}
+}
+
+func (it *Iterator[K, V]) HasNext() bool {
+	if len(it.stack) == 0 {
+		return false
+	}
+	if it.bounded {
+		return it.cmp(it.stack[len(it.stack)-1].Key, it.hi) < 0
+	}
+	return true
+}
+
+// Peek returns the next node without advancing the iterator.
+func (it *Iterator[K, V]) Peek() *Node[K, V] {
+	if !it.HasNext() {
+		panic("iterator must have next")
+	}
+	return it.stack[len(it.stack)-1]
+}
+
+func (it *Iterator[K, V]) Next() *Node[K, V] {
+	if !it.HasNext() {
+		panic("iterator must have next")
+	}
+	n := it.stack[len(it.stack)-1]
+	it.stack = it.stack[:len(it.stack)-1]
+	if it.reverse {
+		it.pushRight(n.left)
+	} else {
+		it.pushLeft(n.right)
+	}
+	return n
+}
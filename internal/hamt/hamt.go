@@ -0,0 +1,324 @@
+// Package hamt implements a persistent hash array mapped trie, which is
+// shared by the persistent hash containers.
+package hamt
+
+import (
+	"math/bits"
+
+	"github.com/shayanh/gcl"
+)
+
+const (
+	bitsPerLevel = 5
+	levelMask    = 1<<bitsPerLevel - 1
+)
+
+// Entry is a key-value pair stored in the trie. Entries are never modified
+// after they are added to a trie, so an entry that is shared by two tries
+// proves that its key did not change between them.
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+
+	hash uint64
+}
+
+// owner marks the nodes that a transient is allowed to modify in place. It
+// is not zero-sized, so that every owner has a distinct address.
+type owner struct {
+	_ byte
+}
+
+// slot is a child of a node. It is either a subnode, or a non-empty bucket
+// of entries whose keys have the same hash.
+type slot[K any, V any] struct {
+	sub     *node[K, V]
+	entries []*Entry[K, V]
+}
+
+// node is a trie node. Bit i of the bitmap is set if the node has a child for
+// the hash chunk i. The children are stored in the slots slice in the order
+// of their bits.
+type node[K any, V any] struct {
+	bitmap uint32
+	slots  []slot[K, V]
+	owner  *owner
+}
+
+// Trie is a persistent hash array mapped trie. A Trie value is immutable. Put
+// and Delete return new tries that share unchanged nodes with the old one.
+type Trie[K any, V any] struct {
+	root *node[K, V]
+	size int
+	hash gcl.HashFn[K]
+	eq   gcl.EqualFn[K, K]
+}
+
+// New creates an empty trie that uses the given hash and equal functions for
+// keys.
+func New[K any, V any](hash gcl.HashFn[K], eq gcl.EqualFn[K, K]) Trie[K, V] {
+	return Trie[K, V]{hash: hash, eq: eq}
+}
+
+// Len returns the number of entries in the trie.
+func (t Trie[K, V]) Len() int {
+	return t.size
+}
+
+// Get returns the entry with key k or nil if there is no such entry.
+func (t Trie[K, V]) Get(k K) *Entry[K, V] {
+	return get(t.root, k, t.hash(k), t.eq)
+}
+
+// Put returns a new trie in which key k is mapped to v.
+func (t Trie[K, V]) Put(k K, v V) Trie[K, V] {
+	e := &Entry[K, V]{Key: k, Value: v, hash: t.hash(k)}
+	root, added := set(t.root, e, 0, t.eq, nil)
+	t.root = root
+	if added {
+		t.size += 1
+	}
+	return t
+}
+
+// Delete returns a new trie without key k. If k is not in the trie, t itself
+// is returned.
+func (t Trie[K, V]) Delete(k K) Trie[K, V] {
+	root, removed := remove(t.root, k, t.hash(k), 0, t.eq, nil)
+	if removed {
+		t.root = root
+		t.size -= 1
+	}
+	return t
+}
+
+// Transient returns a transient copy of the trie, which can be modified in
+// place. Creating a transient is O(1), nodes are copied on their first
+// modification only.
+func (t Trie[K, V]) Transient() *Transient[K, V] {
+	return &Transient[K, V]{trie: t, owner: &owner{}}
+}
+
+// Transient is a mutable version of a trie, which is useful for bulk
+// updates. Nodes created by a transient are owned by it and modified in
+// place by later updates of the same transient.
+type Transient[K any, V any] struct {
+	trie  Trie[K, V]
+	owner *owner
+}
+
+// Len returns the number of entries in the transient.
+func (tr *Transient[K, V]) Len() int {
+	return tr.trie.size
+}
+
+// Get returns the entry with key k or nil if there is no such entry.
+func (tr *Transient[K, V]) Get(k K) *Entry[K, V] {
+	return tr.trie.Get(k)
+}
+
+// Put maps key k to v. It returns true if k is a new key.
+func (tr *Transient[K, V]) Put(k K, v V) bool {
+	t := &tr.trie
+	e := &Entry[K, V]{Key: k, Value: v, hash: t.hash(k)}
+	root, added := set(t.root, e, 0, t.eq, tr.owner)
+	t.root = root
+	if added {
+		t.size += 1
+	}
+	return added
+}
+
+// Delete deletes key k. It returns true if k was in the transient.
+func (tr *Transient[K, V]) Delete(k K) bool {
+	t := &tr.trie
+	root, removed := remove(t.root, k, t.hash(k), 0, t.eq, tr.owner)
+	if removed {
+		t.root = root
+		t.size -= 1
+	}
+	return removed
+}
+
+// Persistent returns the current content of the transient as an immutable
+// trie. The transient stays usable, but later updates no longer modify the
+// nodes of the returned trie.
+func (tr *Transient[K, V]) Persistent() Trie[K, V] {
+	tr.owner = &owner{}
+	return tr.trie
+}
+
+func chunk(hash uint64, shift uint) uint32 {
+	return uint32(hash>>shift) & levelMask
+}
+
+// pos returns the position of the slot for the given bit in n.slots.
+func (n *node[K, V]) pos(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// editable returns n itself if it is owned by o, otherwise a copy of n owned
+// by o. A nil o never owns any node, so persistent updates always copy.
+func (n *node[K, V]) editable(o *owner) *node[K, V] {
+	if o != nil && n.owner == o {
+		return n
+	}
+	slots := make([]slot[K, V], len(n.slots), len(n.slots)+1)
+	copy(slots, n.slots)
+	return &node[K, V]{bitmap: n.bitmap, slots: slots, owner: o}
+}
+
+func get[K any, V any](n *node[K, V], k K, h uint64, eq gcl.EqualFn[K, K]) *Entry[K, V] {
+	for shift := uint(0); n != nil; shift += bitsPerLevel {
+		bit := uint32(1) << chunk(h, shift)
+		if n.bitmap&bit == 0 {
+			return nil
+		}
+		s := n.slots[n.pos(bit)]
+		if s.sub == nil {
+			if s.entries[0].hash != h {
+				return nil
+			}
+			for _, e := range s.entries {
+				if eq(e.Key, k) {
+					return e
+				}
+			}
+			return nil
+		}
+		n = s.sub
+	}
+	return nil
+}
+
+// set returns node n with entry e added to it, replacing the entry with the
+// same key if there is one. It also returns true if the key of e is new.
+func set[K any, V any](n *node[K, V], e *Entry[K, V], shift uint, eq gcl.EqualFn[K, K], o *owner) (*node[K, V], bool) {
+	if n == nil {
+		n = &node[K, V]{owner: o}
+	}
+	bit := uint32(1) << chunk(e.hash, shift)
+	i := n.pos(bit)
+	if n.bitmap&bit == 0 {
+		res := n.editable(o)
+		res.slots = append(res.slots, slot[K, V]{})
+		copy(res.slots[i+1:], res.slots[i:])
+		res.slots[i] = slot[K, V]{entries: []*Entry[K, V]{e}}
+		res.bitmap |= bit
+		return res, true
+	}
+
+	s := n.slots[i]
+	added := true
+	switch {
+	case s.sub != nil:
+		s.sub, added = set(s.sub, e, shift+bitsPerLevel, eq, o)
+	case s.entries[0].hash == e.hash:
+		// Buckets may be shared with older nodes, so they are always copied.
+		entries := make([]*Entry[K, V], len(s.entries), len(s.entries)+1)
+		copy(entries, s.entries)
+		for j, old := range entries {
+			if eq(old.Key, e.Key) {
+				entries[j] = e
+				added = false
+				break
+			}
+		}
+		if added {
+			entries = append(entries, e)
+		}
+		s.entries = entries
+	default:
+		s = slot[K, V]{sub: split(s.entries, e, shift+bitsPerLevel, o)}
+	}
+	res := n.editable(o)
+	res.slots[i] = s
+	return res, added
+}
+
+// split returns a node containing the given bucket and entry e, whose hashes
+// are different.
+func split[K any, V any](entries []*Entry[K, V], e *Entry[K, V], shift uint, o *owner) *node[K, V] {
+	c1, c2 := chunk(entries[0].hash, shift), chunk(e.hash, shift)
+	if c1 == c2 {
+		return &node[K, V]{
+			bitmap: 1 << c1,
+			slots:  []slot[K, V]{{sub: split(entries, e, shift+bitsPerLevel, o)}},
+			owner:  o,
+		}
+	}
+	s1, s2 := slot[K, V]{entries: entries}, slot[K, V]{entries: []*Entry[K, V]{e}}
+	if c1 > c2 {
+		s1, s2 = s2, s1
+	}
+	return &node[K, V]{
+		bitmap: 1<<c1 | 1<<c2,
+		slots:  []slot[K, V]{s1, s2},
+		owner:  o,
+	}
+}
+
+// remove returns node n without key k, or nil if the node becomes empty. It
+// also returns true if k was found.
+func remove[K any, V any](n *node[K, V], k K, h uint64, shift uint, eq gcl.EqualFn[K, K], o *owner) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	bit := uint32(1) << chunk(h, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	i := n.pos(bit)
+	s := n.slots[i]
+	if s.sub != nil {
+		sub, removed := remove(s.sub, k, h, shift+bitsPerLevel, eq, o)
+		if !removed {
+			return n, false
+		}
+		switch {
+		case sub == nil:
+			s = slot[K, V]{}
+		case len(sub.slots) == 1 && sub.slots[0].sub == nil:
+			// A subnode holding a single bucket is collapsed into its parent.
+			s = sub.slots[0]
+		default:
+			s.sub = sub
+		}
+	} else {
+		if s.entries[0].hash != h {
+			return n, false
+		}
+		j := -1
+		for idx, e := range s.entries {
+			if eq(e.Key, k) {
+				j = idx
+				break
+			}
+		}
+		if j < 0 {
+			return n, false
+		}
+		if len(s.entries) == 1 {
+			s = slot[K, V]{}
+		} else {
+			entries := make([]*Entry[K, V], 0, len(s.entries)-1)
+			entries = append(entries, s.entries[:j]...)
+			s.entries = append(entries, s.entries[j+1:]...)
+		}
+	}
+
+	if s.sub == nil && s.entries == nil {
+		if n.bitmap == bit {
+			return nil, true
+		}
+		res := n.editable(o)
+		copy(res.slots[i:], res.slots[i+1:])
+		res.slots[len(res.slots)-1] = slot[K, V]{}
+		res.slots = res.slots[:len(res.slots)-1]
+		res.bitmap &^= bit
+		return res, true
+	}
+	res := n.editable(o)
+	res.slots[i] = s
+	return res, true
+}
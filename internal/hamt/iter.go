@@ -0,0 +1,140 @@
+package hamt
+
+import (
+	"github.com/shayanh/gcl"
+)
+
+// Iterator is an iterator over the entries of a trie. The iteration order is
+// determined by the hashes of the keys.
+type Iterator[K any, V any] struct {
+	// stack holds the slots that are not visited yet, one slice per level.
+	stack [][]slot[K, V]
+	// entries holds the entries of the current bucket that are not visited
+	// yet.
+	entries []*Entry[K, V]
+}
+
+// Iter returns an iterator over the entries of the trie.
+func (t Trie[K, V]) Iter() *Iterator[K, V] {
+	it := &Iterator[K, V]{}
+	if t.root != nil {
+		it.stack = append(it.stack, t.root.slots)
+	}
+	it.fill()
+	return it
+}
+
+// fill makes sure that it.entries is not empty unless the iteration is over.
+func (it *Iterator[K, V]) fill() {
+	for len(it.entries) == 0 && len(it.stack) > 0 {
+		top := &it.stack[len(it.stack)-1]
+		if len(*top) == 0 {
+			it.stack = it.stack[:len(it.stack)-1]
+			continue
+		}
+		s := (*top)[0]
+		*top = (*top)[1:]
+		if s.sub != nil {
+			it.stack = append(it.stack, s.sub.slots)
+		} else {
+			it.entries = s.entries
+		}
+	}
+}
+
+func (it *Iterator[K, V]) HasNext() bool {
+	return len(it.entries) > 0
+}
+
+func (it *Iterator[K, V]) Next() *Entry[K, V] {
+	if !it.HasNext() {
+		panic("iterator must have next")
+	}
+	e := it.entries[0]
+	it.entries = it.entries[1:]
+	it.fill()
+	return e
+}
+
+// Diff returns the keys that are added, deleted or updated in trie t
+// compared to trie old. Both tries must use the same hash function. Keys are
+// compared with the equal function of t. A key counts as updated if it was
+// put again, even with an equal value. Subtries that are shared between the
+// two tries are skipped, so diffing two versions of a trie is proportional
+// to the size of their difference rather than to their length.
+func (t Trie[K, V]) Diff(old Trie[K, V]) []K {
+	var res []K
+	diff(old.root, t.root, t.eq, &res)
+	return res
+}
+
+func diff[K any, V any](a, b *node[K, V], eq gcl.EqualFn[K, K], res *[]K) {
+	if a == b {
+		return
+	}
+	var abits, bbits uint32
+	if a != nil {
+		abits = a.bitmap
+	}
+	if b != nil {
+		bbits = b.bitmap
+	}
+	for all := abits | bbits; all != 0; all &= all - 1 {
+		bit := all & -all
+		var sa, sb *slot[K, V]
+		if abits&bit != 0 {
+			sa = &a.slots[a.pos(bit)]
+		}
+		if bbits&bit != 0 {
+			sb = &b.slots[b.pos(bit)]
+		}
+		switch {
+		case sa != nil && sb != nil && sa.sub != nil && sb.sub != nil:
+			diff(sa.sub, sb.sub, eq, res)
+		default:
+			diffEntries(entriesOf(sa), entriesOf(sb), eq, res)
+		}
+	}
+}
+
+// entriesOf returns all entries under the given slot.
+func entriesOf[K any, V any](s *slot[K, V]) []*Entry[K, V] {
+	if s == nil {
+		return nil
+	}
+	if s.sub == nil {
+		return s.entries
+	}
+	var res []*Entry[K, V]
+	it := &Iterator[K, V]{stack: [][]slot[K, V]{s.sub.slots}}
+	for it.fill(); it.HasNext(); {
+		res = append(res, it.Next())
+	}
+	return res
+}
+
+// diffEntries appends the keys of a and b that are not shared by both to
+// res. a and b are small here, except when a large subtrie was replaced with
+// a bucket or the other way around, in which case most of their keys are
+// part of the difference anyway.
+func diffEntries[K any, V any](a, b []*Entry[K, V], eq gcl.EqualFn[K, K], res *[]K) {
+	matched := make([]bool, len(b))
+outer:
+	for _, ea := range a {
+		for j, eb := range b {
+			if !matched[j] && ea.hash == eb.hash && eq(ea.Key, eb.Key) {
+				matched[j] = true
+				if ea != eb {
+					*res = append(*res, eb.Key)
+				}
+				continue outer
+			}
+		}
+		*res = append(*res, ea.Key)
+	}
+	for j, eb := range b {
+		if !matched[j] {
+			*res = append(*res, eb.Key)
+		}
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"github.com/shayanh/gcl/iters"
 )
 
-func Reverse[T any](fIt iters.Iterator[*T], rIt iters.Iterator[*T], length int) {
-	fIdx, rIdx := 0, length-1
+// Reverse reverses a collection given a forward and a reverse mutable
+// iterator over it, both located at their initial positions. The number of
+// elements is taken from the forward iterator.
+func Reverse[T any](fIt iters.SizedIterator[*T], rIt iters.Iterator[*T]) {
+	fIdx, rIdx := 0, fIt.Remaining()-1
 	for fIdx < rIdx {
 		if !fIt.HasNext() || !rIt.HasNext() {
 			panic("bad iterator")
@@ -0,0 +1,177 @@
+package iters
+
+import (
+	"context"
+	"sync"
+)
+
+// ChanIter is an iterator over the values received from a channel. It is
+// created by FromChan.
+type ChanIter[T any] struct {
+	ch    <-chan T
+	state nextState
+	next  T
+}
+
+// FromChan returns an iterator over the values received from channel ch. The
+// iterator ends when ch is closed. HasNext blocks until a value is received
+// or ch is closed.
+func FromChan[T any](ch <-chan T) *ChanIter[T] {
+	return &ChanIter[T]{ch: ch}
+}
+
+func (it *ChanIter[T]) findNext() {
+	if v, ok := <-it.ch; ok {
+		it.state = hasNext
+		it.next = v
+		return
+	}
+	it.state = noNext
+}
+
+func (it *ChanIter[T]) HasNext() bool {
+	if it.state == unknown {
+		it.findNext()
+	}
+	return it.state == hasNext
+}
+
+func (it *ChanIter[T]) Next() T {
+	if it.state == unknown {
+		it.findNext()
+	}
+	if it.state == noNext {
+		panic("iterator does not have next")
+	}
+	it.state = unknown
+	return it.next
+}
+
+// ToChan starts a goroutine that sends the elements of the given iterator it
+// to the returned channel, which has a buffer of size buf. The channel is
+// closed when it is exhausted or ctx is canceled, whichever happens first.
+// After a cancellation, the goroutine exits without sending the rest of the
+// elements.
+// The given iterator it is consumed by the goroutine, so it must not be used
+// by the caller after calling ToChan.
+func ToChan[T any](ctx context.Context, it Iterator[T], buf int) <-chan T {
+	ch := make(chan T, buf)
+	go func() {
+		defer close(ch)
+		for it.HasNext() {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case ch <- it.Next():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// teeState is the state shared by the iterators returned by Tee.
+type teeState[T any] struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	wrappedIt Iterator[T]
+	done      bool
+	// buf holds the elements that have been read from wrappedIt but not yet
+	// consumed by all iterators. buf[0] is the element at position base.
+	buf   []T
+	base  int
+	limit int
+	// pos holds the position of the next element of each iterator.
+	pos []int
+}
+
+type teeIter[T any] struct {
+	state *teeState[T]
+	id    int
+}
+
+func (it *teeIter[T]) hasNext() bool {
+	s := it.state
+	for {
+		if s.pos[it.id] < s.base+len(s.buf) {
+			return true
+		}
+		if s.done {
+			return false
+		}
+		if !s.wrappedIt.HasNext() {
+			s.done = true
+			continue
+		}
+		if len(s.buf) >= s.limit {
+			// This iterator is ahead of the slowest one by limit elements.
+			s.cond.Wait()
+			continue
+		}
+		s.buf = append(s.buf, s.wrappedIt.Next())
+	}
+}
+
+func (it *teeIter[T]) HasNext() bool {
+	it.state.mu.Lock()
+	defer it.state.mu.Unlock()
+	return it.hasNext()
+}
+
+func (it *teeIter[T]) Next() T {
+	s := it.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !it.hasNext() {
+		panic("iterator does not have next")
+	}
+	v := s.buf[s.pos[it.id]-s.base]
+	s.pos[it.id] += 1
+
+	min := s.pos[0]
+	for _, p := range s.pos[1:] {
+		if p < min {
+			min = p
+		}
+	}
+	if drop := min - s.base; drop > 0 {
+		var zero T
+		for i := 0; i < drop; i++ {
+			s.buf[i] = zero
+		}
+		s.buf = s.buf[drop:]
+		s.base = min
+		s.cond.Broadcast()
+	}
+	return v
+}
+
+// teeBufSize is the maximum number of elements that the iterators returned by
+// Tee buffer.
+const teeBufSize = 64
+
+// Tee splits the given iterator it into n independent iterators that all
+// yield the elements of it. Elements are read from it lazily and buffered
+// until every returned iterator has consumed them. At most 64 elements are
+// buffered: an iterator that is 64 elements ahead of the slowest one blocks
+// in HasNext or Next until the slowest one catches up. So unless the whole
+// input fits in the buffer, the iterators must be consumed by different
+// goroutines, otherwise the caller deadlocks.
+// The returned iterators are safe for concurrent use, each by one goroutine.
+// The given iterator it must not be used after calling Tee.
+func Tee[T any](it Iterator[T], n int) []Iterator[T] {
+	s := &teeState[T]{
+		wrappedIt: it,
+		limit:     teeBufSize,
+		pos:       make([]int, n),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	res := make([]Iterator[T], n)
+	for i := range res {
+		res[i] = &teeIter[T]{state: s, id: i}
+	}
+	return res
+}
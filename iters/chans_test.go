@@ -0,0 +1,130 @@
+package iters_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+	"golang.org/x/exp/slices"
+)
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		for i := 1; i <= 3; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+	if !iters.Equal[int](iters.FromChan(ch), goslices.Iter([]int{1, 2, 3})) {
+		t.Error("Wrong FromChan result")
+	}
+}
+
+func TestToChan(t *testing.T) {
+	ch := iters.ToChan[int](context.Background(), goslices.Iter([]int{1, 2, 3}), 1)
+	var got []int
+	for v := range ch {
+		got = append(got, v)
+	}
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("ToChan yielded %v, want %v", got, want)
+	}
+}
+
+func TestToChanCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := make([]int, 1000)
+	ch := iters.ToChan[int](ctx, goslices.Iter(s), 0)
+	<-ch
+	cancel()
+	n := 0
+	for range ch {
+		n++
+	}
+	// The channel must be closed without sending the rest of the elements.
+	if n > 1 {
+		t.Errorf("received %v elements after cancellation", n)
+	}
+}
+
+func TestTee(t *testing.T) {
+	// The whole input fits in the buffer, so the iterators can be consumed
+	// one after another.
+	its := iters.Tee[int](goslices.Iter([]int{1, 2, 3}), 3)
+	if !iters.Equal[int](its[0], goslices.Iter([]int{1, 2, 3})) {
+		t.Error("Wrong Tee result")
+	}
+	if its[1].Next() != 1 {
+		t.Error("Wrong Tee result")
+	}
+	if !iters.Equal[int](its[2], goslices.Iter([]int{1, 2, 3})) {
+		t.Error("Wrong Tee result")
+	}
+	if !iters.Equal[int](its[1], goslices.Iter([]int{2, 3})) {
+		t.Error("Wrong Tee result")
+	}
+}
+
+func TestTeeBounded(t *testing.T) {
+	s := make([]int, 1000)
+	for i := range s {
+		s[i] = i
+	}
+	its := iters.Tee[int](goslices.Iter(s), 4)
+	results := make([][]int, len(its))
+	var wg sync.WaitGroup
+	for i, it := range its {
+		wg.Add(1)
+		go func(i int, it iters.Iterator[int]) {
+			defer wg.Done()
+			results[i] = goslices.FromIter(it)
+		}(i, it)
+	}
+	wg.Wait()
+	for i, got := range results {
+		if !slices.Equal(got, s) {
+			t.Errorf("iterator %v yielded %v elements, want %v", i, len(got), len(s))
+		}
+	}
+}
+
+// countingIter counts the elements read from the wrapped iterator.
+type countingIter struct {
+	iters.Iterator[int]
+	mu sync.Mutex
+	n  int
+}
+
+func (it *countingIter) Next() int {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.n++
+	return it.Iterator.Next()
+}
+
+func TestTeeBufferLimit(t *testing.T) {
+	s := make([]int, 1000)
+	src := &countingIter{Iterator: goslices.Iter(s)}
+	its := iters.Tee[int](src, 2)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		goslices.FromIter(its[0])
+	}()
+	// its[0] blocks once it is far enough ahead of its[1].
+	time.Sleep(20 * time.Millisecond)
+	src.mu.Lock()
+	n := src.n
+	src.mu.Unlock()
+	if n == 0 || n >= len(s) {
+		t.Errorf("Tee read %v elements ahead of a stalled iterator", n)
+	}
+	if got := goslices.FromIter(its[1]); len(got) != len(s) {
+		t.Errorf("its[1] yielded %v elements, want %v", len(got), len(s))
+	}
+	<-done
+}
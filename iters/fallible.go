@@ -0,0 +1,124 @@
+package iters
+
+// FallibleIterator is an iterator that can fail, for example while reading
+// from a file or a database cursor. When a FallibleIterator fails, HasNext
+// returns false and Err returns the error. Err returns nil if the iterator has
+// not failed, including when it has reached its end normally.
+type FallibleIterator[T any] interface {
+	Iterator[T]
+
+	// Err returns the first error that was encountered by the iterator.
+	Err() error
+}
+
+// Err returns the error of the given iterator it if it is a FallibleIterator,
+// and nil otherwise. It is meant to be called after it.HasNext() returns
+// false, to tell apart the end of the iteration from a failure.
+//
+// Iterators returned by Map, Filter and Zip are FallibleIterators that report
+// the errors of the iterators they wrap.
+func Err[T any](it Iterator[T]) error {
+	if fit, ok := it.(FallibleIterator[T]); ok {
+		return fit.Err()
+	}
+	return nil
+}
+
+type tryMapIter[T any, V any] struct {
+	wrappedIt Iterator[T]
+	fn        func(T) (V, error)
+	state     nextState
+	next      V
+	err       error
+}
+
+func (it *tryMapIter[T, V]) findNext() {
+	if it.err == nil && it.wrappedIt.HasNext() {
+		v, err := it.fn(it.wrappedIt.Next())
+		if err == nil {
+			it.state = hasNext
+			it.next = v
+			return
+		}
+		it.err = err
+	}
+	it.state = noNext
+}
+
+func (it *tryMapIter[T, V]) HasNext() bool {
+	if it.state == unknown {
+		it.findNext()
+	}
+	return it.state == hasNext
+}
+
+func (it *tryMapIter[T, V]) Next() V {
+	if it.state == unknown {
+		it.findNext()
+	}
+	if it.state == noNext {
+		panic("iterator does not have next")
+	}
+	it.state = unknown
+	return it.next
+}
+
+func (it *tryMapIter[T, V]) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return Err(it.wrappedIt)
+}
+
+// TryMap works the same as Map, but the function fn can fail. The returned
+// iterator stops at the first error returned by fn or by the given iterator
+// it, and reports that error through its Err method.
+// TryMap is lazy, in a way that if you don't consume the returned iterator
+// nothing will happen.
+func TryMap[T any, V any](it Iterator[T], fn func(T) (V, error)) FallibleIterator[V] {
+	return &tryMapIter[T, V]{wrappedIt: it, fn: fn}
+}
+
+// TryFold works the same as Fold, but the function fn can fail. TryFold stops
+// at the first error returned by fn or by the given iterator it, and returns
+// the accumulated value so far together with that error.
+func TryFold[T any, V any](it Iterator[T], fn func(V, T) (V, error), init V) (acc V, err error) {
+	acc = init
+	for it.HasNext() {
+		next, err := fn(acc, it.Next())
+		if err != nil {
+			return acc, err
+		}
+		acc = next
+	}
+	return acc, Err(it)
+}
+
+// TryFind works the same as Find, but the predicate pred can fail. TryFind
+// stops at the first error returned by pred or by the given iterator it, and
+// returns that error.
+func TryFind[T any](it Iterator[T], pred func(T) (bool, error)) (t T, ok bool, err error) {
+	for it.HasNext() {
+		v := it.Next()
+		found, err := pred(v)
+		if err != nil {
+			return t, false, err
+		}
+		if found {
+			return v, true, nil
+		}
+	}
+	return t, false, Err(it)
+}
+
+// Collect returns the elements of the given iterator it in a slice, together
+// with the error of it, if any. On failure, the returned slice contains the
+// elements before the failure.
+// Collect moves the given iterator it to its end such that after a Collect
+// call it.HasNext() will be false.
+func Collect[T any](it Iterator[T]) (res []T, err error) {
+	for it.HasNext() {
+		res = append(res, it.Next())
+	}
+	return res, Err(it)
+}
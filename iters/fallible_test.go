@@ -0,0 +1,117 @@
+package iters_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+	"golang.org/x/exp/slices"
+)
+
+var errRead = errors.New("read failed")
+
+// failingIter yields the elements of a slice and then fails with errRead.
+type failingIter struct {
+	s   []int
+	err error
+}
+
+func (it *failingIter) HasNext() bool {
+	if len(it.s) == 0 {
+		it.err = errRead
+		return false
+	}
+	return true
+}
+
+func (it *failingIter) Next() int {
+	v := it.s[0]
+	it.s = it.s[1:]
+	return v
+}
+
+func (it *failingIter) Err() error {
+	return it.err
+}
+
+func TestErrPropagation(t *testing.T) {
+	double := func(n int) int { return n * 2 }
+	even := func(n int) bool { return n%2 == 0 }
+
+	it := iters.Map[int](iters.Filter[int](&failingIter{s: []int{1, 2, 3, 4}}, even), double)
+	got, err := iters.Collect(it)
+	if !slices.Equal(got, []int{4, 8}) || err != errRead {
+		t.Errorf("Collect(Map(Filter(it))) = (%v, %v), want = (%v, %v)", got, err, []int{4, 8}, errRead)
+	}
+
+	zipped := iters.Zip[int, int](goslices.Iter([]int{1, 2, 3}), &failingIter{s: []int{1}})
+	if zs, err := iters.Collect(zipped); len(zs) != 1 || err != errRead {
+		t.Errorf("Collect(Zip(it1, it2)) = (%v, %v), want one element and %v", zs, err, errRead)
+	}
+
+	if _, err := iters.Collect[int](goslices.Iter([]int{1})); err != nil {
+		t.Errorf("Collect of a non-fallible iterator must not fail, got %v", err)
+	}
+
+	sum := func(a, b int) int { return a + b }
+	fit := &failingIter{s: []int{1, 2}}
+	if res := iters.Fold[int](fit, sum, 0); res != 3 || iters.Err[int](fit) != errRead {
+		t.Errorf("Fold = %v with error %v, want = 3 with error %v", res, iters.Err[int](fit), errRead)
+	}
+	fit = &failingIter{s: []int{1, 3}}
+	if _, ok := iters.Find[int](fit, even); ok || iters.Err[int](fit) != errRead {
+		t.Errorf("Find must report the iterator failure")
+	}
+}
+
+func TestTryMap(t *testing.T) {
+	it := iters.TryMap[string](goslices.Iter([]string{"1", "2", "x", "4"}), strconv.Atoi)
+	got, err := iters.Collect[int](it)
+	if !slices.Equal(got, []int{1, 2}) || err == nil {
+		t.Errorf("Collect(TryMap) = (%v, %v), want = (%v, non-nil)", got, err, []int{1, 2})
+	}
+
+	it = iters.TryMap[string](goslices.Iter([]string{"1", "2"}), strconv.Atoi)
+	if got, err := iters.Collect[int](it); !slices.Equal(got, []int{1, 2}) || err != nil {
+		t.Errorf("Collect(TryMap) = (%v, %v), want = (%v, nil)", got, err, []int{1, 2})
+	}
+
+	it = iters.TryMap[int](&failingIter{s: []int{1}}, func(n int) (int, error) { return n, nil })
+	if got, err := iters.Collect[int](it); !slices.Equal(got, []int{1}) || err != errRead {
+		t.Errorf("Collect(TryMap) = (%v, %v), want = (%v, %v)", got, err, []int{1}, errRead)
+	}
+}
+
+func TestTryFoldTryFind(t *testing.T) {
+	errNegative := errors.New("negative")
+	sum := func(acc, n int) (int, error) {
+		if n < 0 {
+			return acc, errNegative
+		}
+		return acc + n, nil
+	}
+	if res, err := iters.TryFold[int](goslices.Iter([]int{1, 2, -1, 4}), sum, 0); res != 3 || err != errNegative {
+		t.Errorf("TryFold = (%v, %v), want = (3, %v)", res, err, errNegative)
+	}
+	if res, err := iters.TryFold[int](&failingIter{s: []int{1, 2}}, sum, 0); res != 3 || err != errRead {
+		t.Errorf("TryFold = (%v, %v), want = (3, %v)", res, err, errRead)
+	}
+
+	isTwo := func(n int) (bool, error) {
+		if n < 0 {
+			return false, errNegative
+		}
+		return n == 2, nil
+	}
+	if v, ok, err := iters.TryFind[int](goslices.Iter([]int{1, 2, -1}), isTwo); v != 2 || !ok || err != nil {
+		t.Errorf("TryFind = (%v, %v, %v), want = (2, true, nil)", v, ok, err)
+	}
+	if _, ok, err := iters.TryFind[int](goslices.Iter([]int{1, -1, 2}), isTwo); ok || err != errNegative {
+		t.Errorf("TryFind = (%v, %v), want = (false, %v)", ok, err, errNegative)
+	}
+	if _, ok, err := iters.TryFind[int](&failingIter{s: []int{1}}, isTwo); ok || err != errRead {
+		t.Errorf("TryFind = (%v, %v), want = (false, %v)", ok, err, errRead)
+	}
+}
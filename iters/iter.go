@@ -12,11 +12,91 @@ type Iterator[T any] interface {
 	Next() T
 }
 
+// SizedIterator is an iterator that knows how many elements are left.
+type SizedIterator[T any] interface {
+	Iterator[T]
+
+	// Remaining returns the number of times Next can be called before
+	// HasNext becomes false.
+	Remaining() int
+}
+
+// BidiIterator is an iterator that can also move backward.
+type BidiIterator[T any] interface {
+	Iterator[T]
+
+	// HasPrev tests whether the iterator can move backward.
+	HasPrev() bool
+
+	// Prev moves the iterator one step backward and returns the value it is
+	// located at afterwards. Prev moves the iterator back to where it was
+	// before the last Next call, so if Next returns the element at position
+	// i, a following Prev returns the element at position i-1.
+	Prev() T
+}
+
+// RandomAccessIterator is an iterator over an indexed collection that can
+// jump to any position in O(1). Positions are the same as the indices of the
+// collection elements, with -1 being the one-before-first position.
+type RandomAccessIterator[T any] interface {
+	BidiIterator[T]
+	SizedIterator[T]
+
+	// Len returns the number of elements in the underlying collection.
+	Len() int
+
+	// Index returns the current position of the iterator. Next returns the
+	// element at position Index() + 1.
+	Index() int
+
+	// Seek moves the iterator to position i, where -1 <= i < Len().
+	Seek(i int)
+}
+
 // Advance advances an iterator n steps. Advance stops at any point
-// where the given iterator doesn't have a next element.
+// where the given iterator doesn't have a next element. If the given iterator
+// is a RandomAccessIterator, Advance is O(1).
 func Advance[T any](it Iterator[T], n uint) {
+	if rit, ok := it.(RandomAccessIterator[T]); ok {
+		if uint(rit.Remaining()) <= n {
+			rit.Seek(rit.Len() - 1)
+		} else {
+			rit.Seek(rit.Index() + int(n))
+		}
+		return
+	}
 	var i uint
 	for i = 0; i < n && it.HasNext(); i++ {
 		it.Next()
 	}
 }
+
+// Nth returns the n-th next element of an iterator, counting from zero, so
+// Nth(it, 0) is the same as it.Next(). The returned boolean value indicates
+// if such an element exists. If it does, the given iterator advances n+1
+// steps, otherwise Nth moves the iterator to its end.
+// If the given iterator is a RandomAccessIterator, Nth is O(1), otherwise it
+// is O(n).
+func Nth[T any](it Iterator[T], n uint) (t T, ok bool) {
+	Advance(it, n)
+	if !it.HasNext() {
+		return
+	}
+	return it.Next(), true
+}
+
+// Len returns the number of elements left in an iterator. If the given
+// iterator is a SizedIterator, Len is O(1) and doesn't advance the iterator.
+// Otherwise, Len moves the given iterator it to its end such that after a Len
+// call it.HasNext() will be false.
+func Len[T any](it Iterator[T]) int {
+	if sit, ok := it.(SizedIterator[T]); ok {
+		return sit.Remaining()
+	}
+	n := 0
+	for it.HasNext() {
+		it.Next()
+		n++
+	}
+	return n
+}
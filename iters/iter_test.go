@@ -0,0 +1,98 @@
+package iters_test
+
+import (
+	"testing"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+	"github.com/shayanh/gcl/lists"
+)
+
+func TestAdvance(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	for _, it := range []iters.Iterator[int]{goslices.Iter(s), lists.Iter(lists.New(s...))} {
+		iters.Advance(it, 2)
+		if v := it.Next(); v != 3 {
+			t.Errorf("Next() after Advance(it, 2) = %v, want = 3", v)
+		}
+		iters.Advance(it, 10)
+		if it.HasNext() {
+			t.Error("Advance past the end must exhaust the iterator")
+		}
+	}
+}
+
+func TestNth(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	for _, it := range []iters.Iterator[int]{goslices.Iter(s), lists.Iter(lists.New(s...))} {
+		if v, ok := iters.Nth(it, 0); !ok || v != 1 {
+			t.Errorf("Nth(it, 0) = %v, %v, want = 1, true", v, ok)
+		}
+		if v, ok := iters.Nth(it, 2); !ok || v != 4 {
+			t.Errorf("Nth(it, 2) = %v, %v, want = 4, true", v, ok)
+		}
+		if v, ok := iters.Nth(it, 1); ok {
+			t.Errorf("Nth(it, 1) = %v, %v, want = 0, false", v, ok)
+		}
+		if it.HasNext() {
+			t.Error("a failed Nth must exhaust the iterator")
+		}
+	}
+}
+
+func TestLen(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	it := goslices.Iter(s)
+	it.Next()
+	if n := iters.Len[int](it); n != 4 || !it.HasNext() {
+		t.Errorf("Len(it) = %v, want = 4 without advancing", n)
+	}
+	fit := iters.Filter[int](goslices.Iter(s), func(v int) bool { return v%2 == 1 })
+	if n := iters.Len[int](fit); n != 3 || fit.HasNext() {
+		t.Errorf("Len(it) = %v, want = 3", n)
+	}
+}
+
+var binarySearchTests = []struct {
+	s      []int
+	target int
+	pos    int
+	found  bool
+}{
+	{nil, 1, 0, false},
+	{[]int{1, 3, 5, 7}, 0, 0, false},
+	{[]int{1, 3, 5, 7}, 5, 2, true},
+	{[]int{1, 3, 5, 7}, 6, 3, false},
+	{[]int{1, 3, 5, 7}, 8, 4, false},
+	{[]int{1, 2, 2, 2, 3}, 2, 1, true},
+}
+
+func TestBinarySearch(t *testing.T) {
+	for _, test := range binarySearchTests {
+		it := goslices.Iter(test.s)
+		pos, found := iters.BinarySearch[int](it, test.target)
+		if pos != test.pos || found != test.found {
+			t.Errorf("BinarySearch(%v, %v) = %v, %v, want = %v, %v", test.s, test.target, pos, found, test.pos, test.found)
+		}
+		if it.Index() != pos-1 {
+			t.Errorf("after BinarySearch(%v, %v), it.Index() = %v, want = %v", test.s, test.target, it.Index(), pos-1)
+		}
+	}
+}
+
+func TestBinarySearchFunc(t *testing.T) {
+	type pair struct {
+		k string
+		v int
+	}
+	s := []pair{{"a", 1}, {"c", 2}, {"e", 3}}
+	cmp := func(p pair, k string) int { return gcl.Compare(p.k, k) }
+	it := goslices.Iter(s)
+	if pos, found := iters.BinarySearchFunc[pair](it, "c", cmp); pos != 1 || !found || it.Next().v != 2 {
+		t.Errorf("BinarySearchFunc(%v, c) = %v, %v, want = 1, true", s, pos, found)
+	}
+	if pos, found := iters.BinarySearchFunc[pair](it, "d", cmp); pos != 2 || found {
+		t.Errorf("BinarySearchFunc(%v, d) = %v, %v, want = 2, false", s, pos, found)
+	}
+}
@@ -62,6 +62,10 @@ func (it *mapIter[T, V]) Next() V {
 	return it.fn(it.wrappedIt.Next())
 }
 
+func (it *mapIter[T, V]) Err() error {
+	return Err(it.wrappedIt)
+}
+
 // Map applies the function fn on elements the given iterator it and returns a
 // new iterator over the mapped values. Map moves the given iterator it to its
 // end such that after a Map call it.HasNext() will be false.
@@ -90,7 +94,8 @@ func Reduce[T any](it Iterator[T], fn func(T, T) T) (acc T) {
 // the given iterator from the beginning to the end. Fold gives an initial
 // value and start its operation by using the initial value.
 // Fold moves the given iterator it to its end such that after a Fold
-// call it.HasNext() will be false.
+// call it.HasNext() will be false. If it is a FallibleIterator, Err(it) tells
+// whether Fold has stopped because of a failure.
 func Fold[T any, V any](it Iterator[T], fn func(V, T) V, init V) (acc V) {
 	acc = init
 	for it.HasNext() {
@@ -147,6 +152,10 @@ func (it *filterIter[T]) Next() T {
 	return it.next
 }
 
+func (it *filterIter[T]) Err() error {
+	return Err(it.wrappedIt)
+}
+
 // Filter filters elements of an iterator that satisfy the pred.
 // Filter returns an iterator over the filtered elements. Filter moves the given
 // iterator it to its end such that after a Filter call it.HasNext() will be
@@ -160,7 +169,9 @@ func Filter[T any](it Iterator[T], pred func(T) bool) Iterator[T] {
 // Find returns the first element in an iterator that satisfies pred. The
 // returned boolean value indicates if such an element exists. If a satisfying
 // element exists, the given iterator it advances the first satisfying element
-// by one step, otherwise Find moves the iterator it to its end.
+// by one step, otherwise Find moves the iterator it to its end. If it is a
+// FallibleIterator and ok is false, Err(it) tells whether Find has stopped
+// because of a failure.
 func Find[T any](it Iterator[T], pred func(T) bool) (t T, ok bool) {
 	ok = false
 	for it.HasNext() {
@@ -270,6 +281,13 @@ func (it *zipIter[T1, T2]) Next() gcl.Zipped[T1, T2] {
 	}
 }
 
+func (it *zipIter[T1, T2]) Err() error {
+	if err := Err(it.it1); err != nil {
+		return err
+	}
+	return Err(it.it2)
+}
+
 // Zip zips the two given iterators and returns a single iterator over
 // gcl.Zipped values.
 func Zip[T1 any, T2 any](it1 Iterator[T1], it2 Iterator[T2]) Iterator[gcl.Zipped[T1, T2]] {
@@ -278,3 +296,330 @@ func Zip[T1 any, T2 any](it1 Iterator[T1], it2 Iterator[T2]) Iterator[gcl.Zipped
 		it2: it2,
 	}
 }
+
+type takeIter[T any] struct {
+	wrappedIt Iterator[T]
+	n         uint
+}
+
+func (it *takeIter[T]) HasNext() bool {
+	return it.n > 0 && it.wrappedIt.HasNext()
+}
+
+func (it *takeIter[T]) Next() T {
+	if it.n == 0 {
+		panic("iterator does not have next")
+	}
+	it.n -= 1
+	return it.wrappedIt.Next()
+}
+
+// Take returns an iterator over the first n elements of the given iterator it,
+// or fewer if it has fewer elements. Take advances the given iterator it only
+// as far as the returned iterator is consumed.
+// Take is lazy, in a way that if you don't consume the returned iterator
+// nothing will happen.
+func Take[T any](it Iterator[T], n uint) Iterator[T] {
+	return &takeIter[T]{wrappedIt: it, n: n}
+}
+
+type skipIter[T any] struct {
+	wrappedIt Iterator[T]
+	n         uint
+}
+
+func (it *skipIter[T]) skip() {
+	if it.n > 0 {
+		Advance(it.wrappedIt, it.n)
+		it.n = 0
+	}
+}
+
+func (it *skipIter[T]) HasNext() bool {
+	it.skip()
+	return it.wrappedIt.HasNext()
+}
+
+func (it *skipIter[T]) Next() T {
+	it.skip()
+	return it.wrappedIt.Next()
+}
+
+// Skip returns an iterator that skips the first n elements of the given
+// iterator it and yields the rest. Skip moves the given iterator it to its end
+// such that after consuming the returned iterator it.HasNext() will be false.
+// Skip is lazy, in a way that if you don't consume the returned iterator
+// nothing will happen. Unlike Advance, the first n elements are skipped only
+// when the returned iterator is used for the first time.
+func Skip[T any](it Iterator[T], n uint) Iterator[T] {
+	return &skipIter[T]{wrappedIt: it, n: n}
+}
+
+type takeWhileIter[T any] struct {
+	wrappedIt Iterator[T]
+	pred      func(T) bool
+	state     nextState
+	next      T
+}
+
+func (it *takeWhileIter[T]) findNext() {
+	if it.wrappedIt.HasNext() {
+		v := it.wrappedIt.Next()
+		if it.pred(v) {
+			it.state = hasNext
+			it.next = v
+			return
+		}
+	}
+	// noNext is final, so the wrapped iterator is not advanced anymore.
+	it.state = noNext
+}
+
+func (it *takeWhileIter[T]) HasNext() bool {
+	if it.state == unknown {
+		it.findNext()
+	}
+	return it.state == hasNext
+}
+
+func (it *takeWhileIter[T]) Next() T {
+	if it.state == unknown {
+		it.findNext()
+	}
+	if it.state == noNext {
+		panic("iterator does not have next")
+	}
+	it.state = unknown
+	return it.next
+}
+
+// TakeWhile returns an iterator over the leading elements of the given
+// iterator it that satisfy pred. The returned iterator stops at the first
+// element that doesn't satisfy pred. That element is consumed from the given
+// iterator it, but it is not yielded.
+// TakeWhile is lazy, in a way that if you don't consume the returned iterator
+// nothing will happen.
+func TakeWhile[T any](it Iterator[T], pred func(T) bool) Iterator[T] {
+	return &takeWhileIter[T]{wrappedIt: it, pred: pred}
+}
+
+type skipWhileIter[T any] struct {
+	wrappedIt Iterator[T]
+	pred      func(T) bool
+	skipped   bool
+	state     nextState
+	next      T
+}
+
+func (it *skipWhileIter[T]) findNext() {
+	if !it.skipped {
+		it.skipped = true
+		for it.wrappedIt.HasNext() {
+			v := it.wrappedIt.Next()
+			if !it.pred(v) {
+				it.state = hasNext
+				it.next = v
+				return
+			}
+		}
+		it.state = noNext
+		return
+	}
+	if it.wrappedIt.HasNext() {
+		it.state = hasNext
+		it.next = it.wrappedIt.Next()
+		return
+	}
+	it.state = noNext
+}
+
+func (it *skipWhileIter[T]) HasNext() bool {
+	if it.state == unknown {
+		it.findNext()
+	}
+	return it.state == hasNext
+}
+
+func (it *skipWhileIter[T]) Next() T {
+	if it.state == unknown {
+		it.findNext()
+	}
+	if it.state == noNext {
+		panic("iterator does not have next")
+	}
+	it.state = unknown
+	return it.next
+}
+
+// SkipWhile returns an iterator that skips the leading elements of the given
+// iterator it that satisfy pred and yields the rest, starting from the first
+// element that doesn't satisfy pred. SkipWhile moves the given iterator it to
+// its end such that after consuming the returned iterator it.HasNext() will be
+// false.
+// SkipWhile is lazy, in a way that if you don't consume the returned iterator
+// nothing will happen.
+func SkipWhile[T any](it Iterator[T], pred func(T) bool) Iterator[T] {
+	return &skipWhileIter[T]{wrappedIt: it, pred: pred}
+}
+
+type stepByIter[T any] struct {
+	wrappedIt Iterator[T]
+	step      uint
+	started   bool
+	state     nextState
+	next      T
+}
+
+func (it *stepByIter[T]) findNext() {
+	if it.started {
+		Advance(it.wrappedIt, it.step-1)
+	}
+	it.started = true
+	if it.wrappedIt.HasNext() {
+		it.state = hasNext
+		it.next = it.wrappedIt.Next()
+		return
+	}
+	it.state = noNext
+}
+
+func (it *stepByIter[T]) HasNext() bool {
+	if it.state == unknown {
+		it.findNext()
+	}
+	return it.state == hasNext
+}
+
+func (it *stepByIter[T]) Next() T {
+	if it.state == unknown {
+		it.findNext()
+	}
+	if it.state == noNext {
+		panic("iterator does not have next")
+	}
+	it.state = unknown
+	return it.next
+}
+
+// StepBy returns an iterator that yields the first element of the given
+// iterator it and then every step-th element after it. For example, StepBy
+// with step 2 yields the elements at positions 0, 2, 4 and so on. StepBy
+// panics if step is zero.
+// StepBy is lazy, in a way that if you don't consume the returned iterator
+// nothing will happen.
+func StepBy[T any](it Iterator[T], step uint) Iterator[T] {
+	if step == 0 {
+		panic("step must be positive")
+	}
+	return &stepByIter[T]{wrappedIt: it, step: step}
+}
+
+type chainIter[T any] struct {
+	its []Iterator[T]
+}
+
+func (it *chainIter[T]) HasNext() bool {
+	for len(it.its) > 0 {
+		if it.its[0].HasNext() {
+			return true
+		}
+		it.its = it.its[1:]
+	}
+	return false
+}
+
+func (it *chainIter[T]) Next() T {
+	if !it.HasNext() {
+		panic("iterator does not have next")
+	}
+	return it.its[0].Next()
+}
+
+// Chain returns an iterator that yields the elements of the given iterators
+// one after another: first all elements of its[0], then all elements of
+// its[1], and so on. The given iterators are moved to their end as the
+// returned iterator is consumed.
+// Chain is lazy, in a way that if you don't consume the returned iterator
+// nothing will happen.
+func Chain[T any](its ...Iterator[T]) Iterator[T] {
+	return &chainIter[T]{its: append([]Iterator[T](nil), its...)}
+}
+
+type flattenIter[T any] struct {
+	wrappedIt Iterator[Iterator[T]]
+	cur       Iterator[T]
+}
+
+func (it *flattenIter[T]) HasNext() bool {
+	for it.cur == nil || !it.cur.HasNext() {
+		if !it.wrappedIt.HasNext() {
+			return false
+		}
+		it.cur = it.wrappedIt.Next()
+	}
+	return true
+}
+
+func (it *flattenIter[T]) Next() T {
+	if !it.HasNext() {
+		panic("iterator does not have next")
+	}
+	return it.cur.Next()
+}
+
+// Flatten takes an iterator over iterators and returns a single iterator over
+// the elements of the inner iterators, in order. Each inner iterator is taken
+// from the given iterator it only when the previous one is exhausted.
+// Flatten is lazy, in a way that if you don't consume the returned iterator
+// nothing will happen.
+func Flatten[T any](it Iterator[Iterator[T]]) Iterator[T] {
+	return &flattenIter[T]{wrappedIt: it}
+}
+
+// FlatMap applies the function fn on elements of the given iterator it and
+// returns a single iterator over the elements of the resulting iterators. It
+// is the same as Flatten(Map(it, fn)).
+// FlatMap is lazy, in a way that if you don't consume the returned iterator
+// nothing will happen.
+func FlatMap[T any, V any](it Iterator[T], fn func(T) Iterator[V]) Iterator[V] {
+	return Flatten(Map(it, fn))
+}
+
+type interleaveIter[T any] struct {
+	its []Iterator[T]
+	idx int
+}
+
+func (it *interleaveIter[T]) HasNext() bool {
+	for len(it.its) > 0 {
+		if it.idx >= len(it.its) {
+			it.idx = 0
+		}
+		if it.its[it.idx].HasNext() {
+			return true
+		}
+		// Drop the exhausted iterator. The next iterator in turn moves to
+		// the same index.
+		it.its = append(it.its[:it.idx], it.its[it.idx+1:]...)
+	}
+	return false
+}
+
+func (it *interleaveIter[T]) Next() T {
+	if !it.HasNext() {
+		panic("iterator does not have next")
+	}
+	v := it.its[it.idx].Next()
+	it.idx += 1
+	return v
+}
+
+// Interleave returns an iterator that takes elements from the given iterators
+// in a round-robin fashion: first element of its[0], first element of its[1],
+// ..., second element of its[0], and so on. Exhausted iterators are skipped,
+// so all elements of all iterators are yielded.
+// Interleave is lazy, in a way that if you don't consume the returned iterator
+// nothing will happen.
+func Interleave[T any](its ...Iterator[T]) Iterator[T] {
+	return &interleaveIter[T]{its: append([]Iterator[T](nil), its...)}
+}
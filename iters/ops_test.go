@@ -269,3 +269,116 @@ func TestZip(t *testing.T) {
 		t.Errorf("it2.HasNext() must be true")
 	}
 }
+
+func isSmall(n int) bool {
+	return n < 3
+}
+
+var adapterTests = []struct {
+	name string
+	fn   func(iters.Iterator[int]) iters.Iterator[int]
+	s    []int
+	want []int
+}{
+	{"Take(2)", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.Take(it, 2) }, []int{1, 2, 3}, []int{1, 2}},
+	{"Take(5)", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.Take(it, 5) }, []int{1, 2, 3}, []int{1, 2, 3}},
+	{"Take(0)", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.Take(it, 0) }, []int{1, 2, 3}, nil},
+	{"Skip(2)", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.Skip(it, 2) }, []int{1, 2, 3}, []int{3}},
+	{"Skip(5)", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.Skip(it, 5) }, []int{1, 2, 3}, nil},
+	{"TakeWhile", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.TakeWhile(it, isSmall) }, []int{1, 2, 3, 1}, []int{1, 2}},
+	{"TakeWhile", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.TakeWhile(it, isSmall) }, []int{3, 1}, nil},
+	{"SkipWhile", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.SkipWhile(it, isSmall) }, []int{1, 2, 3, 1}, []int{3, 1}},
+	{"SkipWhile", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.SkipWhile(it, isSmall) }, []int{1, 2}, nil},
+	{"StepBy(1)", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.StepBy(it, 1) }, []int{1, 2, 3}, []int{1, 2, 3}},
+	{"StepBy(2)", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.StepBy(it, 2) }, []int{1, 2, 3, 4, 5}, []int{1, 3, 5}},
+	{"StepBy(3)", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.StepBy(it, 3) }, []int{1, 2, 3, 4, 5, 6}, []int{1, 4}},
+	{"StepBy(2)", func(it iters.Iterator[int]) iters.Iterator[int] { return iters.StepBy(it, 2) }, nil, nil},
+}
+
+func TestAdapters(t *testing.T) {
+	for _, test := range adapterTests {
+		got := test.fn(goslices.Iter(test.s))
+		if !iters.Equal[int](got, goslices.Iter(test.want)) {
+			t.Errorf("%v on %v: wrong result, want %v", test.name, test.s, test.want)
+		}
+		if got.HasNext() {
+			t.Errorf("%v on %v: HasNext() must be false after Equal", test.name, test.s)
+		}
+	}
+}
+
+func TestAdaptersLaziness(t *testing.T) {
+	it := goslices.Iter([]int{1, 2, 3, 4})
+	taken := iters.Take[int](it, 2)
+	if taken.Next() != 1 || taken.Next() != 2 || taken.HasNext() {
+		t.Errorf("wrong Take result")
+	}
+	if !it.HasNext() || it.Next() != 3 {
+		t.Errorf("Take must not advance the wrapped iterator past n elements")
+	}
+
+	it = goslices.Iter([]int{1, 2, 3, 4})
+	skipped := iters.Skip[int](it, 2)
+	if it.Next() != 1 {
+		t.Errorf("Skip must not advance the wrapped iterator before use")
+	}
+	if skipped.Next() != 4 {
+		t.Errorf("wrong Skip result")
+	}
+
+	// TakeWhile must stay exhausted after the first failing element.
+	it = goslices.Iter([]int{1, 5, 1})
+	tw := iters.TakeWhile[int](it, isSmall)
+	iters.ForEach(tw, func(int) {})
+	if tw.HasNext() || !it.HasNext() {
+		t.Errorf("TakeWhile must stop at the first failing element")
+	}
+}
+
+func TestChain(t *testing.T) {
+	got := iters.Chain[int](
+		goslices.Iter([]int{1, 2}),
+		goslices.Iter([]int{}),
+		goslices.Iter([]int{3}),
+	)
+	if !iters.Equal[int](got, goslices.Iter([]int{1, 2, 3})) {
+		t.Error("Wrong Chain result")
+	}
+	if iters.Chain[int]().HasNext() {
+		t.Error("Chain() must be empty")
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	its := goslices.Iter([]iters.Iterator[int]{
+		goslices.Iter([]int{}),
+		goslices.Iter([]int{1}),
+		goslices.Iter([]int{2, 3}),
+		goslices.Iter([]int{}),
+	})
+	got := iters.Flatten[int](its)
+	if !iters.Equal[int](got, goslices.Iter([]int{1, 2, 3})) {
+		t.Error("Wrong Flatten result")
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	got := iters.FlatMap[int](goslices.Iter([]int{1, 2, 3}), func(n int) iters.Iterator[string] {
+		return iters.Take[string](goslices.Iter([]string{"a", "b", "c"}), uint(n-1))
+	})
+	if !iters.Equal[string](got, goslices.Iter([]string{"a", "a", "b"})) {
+		t.Error("Wrong FlatMap result")
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	got := iters.Interleave[int](
+		goslices.Iter([]int{1, 4, 6}),
+		goslices.Iter([]int{}),
+		goslices.Iter([]int{2}),
+		goslices.Iter([]int{3, 5}),
+	)
+	if !iters.Equal[int](got, goslices.Iter([]int{1, 2, 3, 4, 5, 6})) {
+		t.Error("Wrong Interleave result")
+	}
+}
@@ -0,0 +1,178 @@
+package iters
+
+import (
+	"context"
+	"sync"
+)
+
+type parJob[T any] struct {
+	idx int
+	v   T
+}
+
+type parResult[V any] struct {
+	idx      int
+	v        V
+	panicked bool
+	panicVal any
+}
+
+type parMapIter[V any] struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	results <-chan parResult[V]
+	// Every element taken from the wrapped iterator holds a token until it
+	// is yielded. This bounds the number of elements that are in progress or
+	// waiting to be reordered.
+	tokens  chan struct{}
+	ordered bool
+	pending map[int]parResult[V]
+	nextIdx int
+	state   nextState
+	next    parResult[V]
+}
+
+func parMap[T any, V any](ctx context.Context, it Iterator[T], fn func(T) V, workers int, ordered bool) *parMapIter[V] {
+	if workers <= 0 {
+		panic("workers must be positive")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	window := 2 * workers
+	jobs := make(chan parJob[T], workers)
+	results := make(chan parResult[V], window)
+	pit := &parMapIter[V]{
+		ctx:     ctx,
+		cancel:  cancel,
+		results: results,
+		tokens:  make(chan struct{}, window),
+		ordered: ordered,
+		pending: make(map[int]parResult[V]),
+	}
+
+	go func() {
+		defer close(jobs)
+		for idx := 0; it.HasNext(); idx++ {
+			select {
+			case pit.tokens <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- parJob[T]{idx: idx, v: it.Next()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				select {
+				case results <- runParJob(fn, job):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return pit
+}
+
+func runParJob[T any, V any](fn func(T) V, job parJob[T]) (res parResult[V]) {
+	res.idx = job.idx
+	defer func() {
+		if r := recover(); r != nil {
+			res.panicked = true
+			res.panicVal = r
+		}
+	}()
+	res.v = fn(job.v)
+	return
+}
+
+func (it *parMapIter[V]) findNext() {
+	for {
+		if it.ordered {
+			if r, ok := it.pending[it.nextIdx]; ok {
+				delete(it.pending, it.nextIdx)
+				it.nextIdx += 1
+				it.state = hasNext
+				it.next = r
+				return
+			}
+		}
+		select {
+		case r, ok := <-it.results:
+			if !ok {
+				it.state = noNext
+				it.cancel()
+				return
+			}
+			if !it.ordered {
+				it.state = hasNext
+				it.next = r
+				return
+			}
+			it.pending[r.idx] = r
+		case <-it.ctx.Done():
+			it.state = noNext
+			return
+		}
+	}
+}
+
+func (it *parMapIter[V]) HasNext() bool {
+	if it.state == unknown {
+		it.findNext()
+	}
+	return it.state == hasNext
+}
+
+func (it *parMapIter[V]) Next() V {
+	if it.state == unknown {
+		it.findNext()
+	}
+	if it.state == noNext {
+		panic("iterator does not have next")
+	}
+	it.state = unknown
+	<-it.tokens
+	if it.next.panicked {
+		it.state = noNext
+		it.cancel()
+		panic(it.next.panicVal)
+	}
+	return it.next.v
+}
+
+// ParMap applies the function fn on elements of the given iterator it using
+// a pool of workers goroutines, and returns an iterator over the mapped
+// values in the same order as the elements of it.
+// At most 2*workers elements are in progress or waiting for an earlier
+// element to finish, so memory stays bounded even if fn is slow for some
+// elements. If fn panics, the panic is recovered in the worker and raised
+// again by the Next call that would have returned its result.
+// The returned iterator ends early if ctx is canceled. Canceling ctx also
+// stops the goroutines, which is needed to release them if the returned
+// iterator is not consumed to its end.
+// The given iterator it is consumed by a separate goroutine, so it must not be
+// used by the caller after calling ParMap.
+func ParMap[T any, V any](ctx context.Context, it Iterator[T], fn func(T) V, workers int) Iterator[V] {
+	return parMap(ctx, it, fn, workers, true)
+}
+
+// ParMapUnordered works the same as ParMap, but the returned iterator yields
+// the mapped values in the order they are finished rather than the order of
+// the elements of it.
+func ParMapUnordered[T any, V any](ctx context.Context, it Iterator[T], fn func(T) V, workers int) Iterator[V] {
+	return parMap(ctx, it, fn, workers, false)
+}
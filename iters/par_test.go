@@ -0,0 +1,97 @@
+package iters_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+	"golang.org/x/exp/slices"
+)
+
+func square(n int) int {
+	// Make later elements finish first, so the results need reordering.
+	time.Sleep(time.Duration(10-n%10) * 10 * time.Microsecond)
+	return n * n
+}
+
+func TestParMap(t *testing.T) {
+	var s, want []int
+	for i := 0; i < 200; i++ {
+		s = append(s, i)
+		want = append(want, i*i)
+	}
+	got := goslices.FromIter(iters.ParMap[int](context.Background(), goslices.Iter(s), square, 4))
+	if !slices.Equal(got, want) {
+		t.Errorf("ParMap yielded %v, want %v", got, want)
+	}
+
+	got = goslices.FromIter(iters.ParMapUnordered[int](context.Background(), goslices.Iter(s), square, 4))
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("ParMapUnordered yielded %v, want %v", got, want)
+	}
+}
+
+func TestParMapBounded(t *testing.T) {
+	var inFlight, maxInFlight int32
+	fn := func(n int) int {
+		cur := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if cur <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, cur) {
+				break
+			}
+		}
+		return n
+	}
+	s := make([]int, 100)
+	it := iters.ParMap[int](context.Background(), goslices.Iter(s), fn, 2)
+	for it.HasNext() {
+		it.Next()
+		atomic.AddInt32(&inFlight, -1)
+		time.Sleep(10 * time.Microsecond)
+	}
+	if maxInFlight > 4 {
+		t.Errorf("%v elements were in progress at the same time, want at most %v", maxInFlight, 4)
+	}
+}
+
+func TestParMapPanic(t *testing.T) {
+	fn := func(n int) int {
+		if n == 3 {
+			panic("bad element")
+		}
+		return n
+	}
+	it := iters.ParMap[int](context.Background(), goslices.Iter([]int{0, 1, 2, 3, 4}), fn, 2)
+	for i := 0; i < 3; i++ {
+		if v := it.Next(); v != i {
+			t.Errorf("Next() = %v, want = %v", v, i)
+		}
+	}
+	defer func() {
+		if r := recover(); r != "bad element" {
+			t.Errorf("recovered %v, want %v", r, "bad element")
+		}
+	}()
+	it.Next()
+}
+
+func TestParMapCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := make([]int, 1000)
+	it := iters.ParMap[int](ctx, goslices.Iter(s), func(n int) int { return n }, 2)
+	it.Next()
+	cancel()
+	n := 0
+	for it.HasNext() {
+		it.Next()
+		n++
+	}
+	if n > 4 {
+		t.Errorf("got %v elements after cancellation", n)
+	}
+}
@@ -0,0 +1,71 @@
+package iters
+
+// MaxPutBack is the maximum number of values that a PeekableIter can buffer,
+// including a peeked value and values put back but not consumed yet.
+const MaxPutBack = 16
+
+// PeekableIter is an iterator with lookahead. It wraps another iterator and
+// allows looking at the next value without consuming it, and pushing values
+// back to the front of the iteration.
+type PeekableIter[T any] struct {
+	wrappedIt Iterator[T]
+	// buf holds values that come before the rest of wrappedIt. The next value
+	// is at the end of buf.
+	buf []T
+}
+
+// Peekable returns a PeekableIter wrapping the given iterator it. The given
+// iterator it is advanced only as far as the returned iterator is consumed or
+// peeked.
+func Peekable[T any](it Iterator[T]) *PeekableIter[T] {
+	return &PeekableIter[T]{wrappedIt: it}
+}
+
+func (it *PeekableIter[T]) HasNext() bool {
+	return len(it.buf) > 0 || it.wrappedIt.HasNext()
+}
+
+func (it *PeekableIter[T]) Next() T {
+	if n := len(it.buf); n > 0 {
+		v := it.buf[n-1]
+		it.buf = it.buf[:n-1]
+		return v
+	}
+	return it.wrappedIt.Next()
+}
+
+// Peek returns the next value without advancing the iterator. The iterator
+// must have next, otherwise Peek panics.
+func (it *PeekableIter[T]) Peek() T {
+	if len(it.buf) == 0 {
+		if !it.wrappedIt.HasNext() {
+			panic("iterator does not have next")
+		}
+		it.buf = append(it.buf, it.wrappedIt.Next())
+	}
+	return it.buf[len(it.buf)-1]
+}
+
+// NextIf advances the iterator and returns the next value only if it
+// satisfies pred. The returned boolean value indicates if the iterator has
+// advanced.
+func (it *PeekableIter[T]) NextIf(pred func(T) bool) (v T, ok bool) {
+	if !it.HasNext() {
+		return
+	}
+	if next := it.Peek(); pred(next) {
+		return it.Next(), true
+	}
+	return
+}
+
+// PutBack pushes v back to the front of the iteration, so that the next call
+// to Next returns v. Values put back are returned in the reverse order of
+// PutBack calls. A peeked value is buffered as well, and PutBack panics if
+// the iterator already buffers MaxPutBack values.
+func (it *PeekableIter[T]) PutBack(v T) {
+	if len(it.buf) >= MaxPutBack {
+		panic("too many values put back")
+	}
+	it.buf = append(it.buf, v)
+}
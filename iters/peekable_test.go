@@ -0,0 +1,56 @@
+package iters_test
+
+import (
+	"testing"
+
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+)
+
+func TestPeekable(t *testing.T) {
+	wrapped := goslices.Iter([]int{1, 2, 3})
+	it := iters.Peekable[int](wrapped)
+	if it.Peek() != 1 || it.Peek() != 1 {
+		t.Error("Peek must not advance the iterator")
+	}
+	if it.Next() != 1 {
+		t.Error("Next must return the peeked value")
+	}
+	if v, ok := it.NextIf(func(n int) bool { return n > 2 }); ok {
+		t.Errorf("NextIf = (%v, %v), want = (0, false)", v, ok)
+	}
+	if v, ok := it.NextIf(func(n int) bool { return n == 2 }); !ok || v != 2 {
+		t.Errorf("NextIf = (%v, %v), want = (2, true)", v, ok)
+	}
+	if !iters.Equal[int](it, goslices.Iter([]int{3})) {
+		t.Error("Wrong Peekable result")
+	}
+	if _, ok := it.NextIf(func(int) bool { return true }); ok {
+		t.Error("NextIf on an exhausted iterator must not be ok")
+	}
+	if wrapped.HasNext() {
+		t.Errorf("wrapped.HasNext() must be false")
+	}
+}
+
+func TestPeekablePutBack(t *testing.T) {
+	it := iters.Peekable[int](goslices.Iter([]int{3, 4}))
+	it.Peek()
+	it.PutBack(2)
+	it.PutBack(1)
+	if it.Peek() != 1 {
+		t.Errorf("Peek() = %v, want = %v", it.Peek(), 1)
+	}
+	if !iters.Equal[int](it, goslices.Iter([]int{1, 2, 3, 4})) {
+		t.Error("Wrong PutBack result")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("PutBack must panic when the buffer is full")
+		}
+	}()
+	for i := 0; i <= iters.MaxPutBack; i++ {
+		it.PutBack(i)
+	}
+}
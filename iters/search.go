@@ -0,0 +1,45 @@
+package iters
+
+import (
+	"golang.org/x/exp/constraints"
+
+	"github.com/shayanh/gcl"
+)
+
+// BinarySearch searches for target in a random access iterator over a
+// collection sorted in ascending order and returns the position where target
+// is found, or the position where target would appear in the sort order. It
+// also returns a boolean saying whether the target is really found. The whole
+// collection is searched, regardless of the current position of the
+// iterator. After the call, the iterator is located one step before the
+// returned position, so Next returns the found element.
+// This function is O(log(n)), where n is it.Len().
+func BinarySearch[T constraints.Ordered](it RandomAccessIterator[T], target T) (int, bool) {
+	return BinarySearchFunc(it, target, gcl.Compare[T])
+}
+
+// BinarySearchFunc works like BinarySearch, but uses the `cmp` function to
+// compare the elements with the target. cmp(e, target) must return a negative
+// number if e comes before target in the sort order, zero if e matches target,
+// and a positive number otherwise.
+// This function is O(f * log(n)), where n is it.Len() and f is the time
+// complexity of `cmp`.
+func BinarySearchFunc[T any, V any](it RandomAccessIterator[T], target V, cmp gcl.CompareFn[T, V]) (int, bool) {
+	lo, hi := 0, it.Len()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		it.Seek(mid - 1)
+		if cmp(it.Next(), target) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	it.Seek(lo - 1)
+	found := false
+	if lo < it.Len() {
+		found = cmp(it.Next(), target) == 0
+		it.Seek(lo - 1)
+	}
+	return lo, found
+}
@@ -0,0 +1,94 @@
+//go:build go1.23
+
+package iters
+
+import "iter"
+
+// Seq returns an iter.Seq that yields the elements of the given iterator it,
+// so that it can be used in a range loop:
+//
+//	for v := range iters.Seq(it) {
+//		...
+//	}
+//
+// Ranging over the returned sequence advances the given iterator it. If the
+// loop breaks early, the remaining elements stay in it.
+func Seq[T any](it Iterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for it.HasNext() {
+			if !yield(it.Next()) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 returns an iter.Seq2 that yields the elements of the given iterator it
+// together with their zero-based position in the iteration.
+// Ranging over the returned sequence advances the given iterator it. If the
+// loop breaks early, the remaining elements stay in it.
+func Seq2[T any](it Iterator[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; it.HasNext(); i++ {
+			if !yield(i, it.Next()) {
+				return
+			}
+		}
+	}
+}
+
+// SeqIter is an iterator over the values of an iter.Seq. It is created by
+// FromSeq.
+type SeqIter[T any] struct {
+	next  func() (T, bool)
+	stop  func()
+	state nextState
+	v     T
+}
+
+// FromSeq converts a push-style iter.Seq into an Iterator using iter.Pull.
+// The sequence runs on its own coroutine, which is released once the
+// iterator is exhausted. If the iterator is abandoned before its end, Stop
+// must be called to release it.
+func FromSeq[T any](seq iter.Seq[T]) *SeqIter[T] {
+	next, stop := iter.Pull(seq)
+	return &SeqIter[T]{
+		next: next,
+		stop: stop,
+	}
+}
+
+func (it *SeqIter[T]) findNext() {
+	if v, ok := it.next(); ok {
+		it.state = hasNext
+		it.v = v
+		return
+	}
+	it.state = noNext
+	it.stop()
+}
+
+func (it *SeqIter[T]) HasNext() bool {
+	if it.state == unknown {
+		it.findNext()
+	}
+	return it.state == hasNext
+}
+
+func (it *SeqIter[T]) Next() T {
+	if it.state == unknown {
+		it.findNext()
+	}
+	if it.state == noNext {
+		panic("iterator does not have next")
+	}
+	it.state = unknown
+	return it.v
+}
+
+// Stop stops the underlying sequence. After Stop, HasNext returns false. It is
+// safe to call Stop multiple times and after the iterator is exhausted.
+func (it *SeqIter[T]) Stop() {
+	it.state = noNext
+	it.stop()
+}
@@ -0,0 +1,63 @@
+//go:build go1.23
+
+package iters_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+)
+
+func TestSeq(t *testing.T) {
+	var got []int
+	for v := range iters.Seq[int](goslices.Iter([]int{1, 2, 3})) {
+		got = append(got, v)
+	}
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("Seq yielded %v, want %v", got, want)
+	}
+
+	it := goslices.Iter([]int{1, 2, 3})
+	for i, v := range iters.Seq2[int](it) {
+		if i != v-1 {
+			t.Errorf("Seq2 yielded (%v, %v)", i, v)
+		}
+		if v == 2 {
+			break
+		}
+	}
+	if !it.HasNext() || it.Next() != 3 {
+		t.Error("breaking a range loop must keep the remaining elements")
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	it := iters.FromSeq(slices.Values([]int{1, 2, 3}))
+	if !iters.Equal[int](it, goslices.Iter([]int{1, 2, 3})) {
+		t.Error("Wrong FromSeq result")
+	}
+
+	stopped := false
+	seq := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	it = iters.FromSeq[int](seq)
+	if !iters.Equal[int](iters.Take[int](it, 3), goslices.Iter([]int{0, 1, 2})) {
+		t.Error("Wrong FromSeq result for an infinite sequence")
+	}
+	it.Stop()
+	if !stopped {
+		t.Error("Stop must stop the underlying sequence")
+	}
+	if it.HasNext() {
+		t.Error("HasNext must be false after Stop")
+	}
+	it.Stop()
+}
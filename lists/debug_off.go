@@ -0,0 +1,15 @@
+//go:build !gcldebug
+
+package lists
+
+// modCount and iterMods are zero-sized and their methods are no-ops unless
+// the gcldebug build tag is set. See debug_on.go.
+type modCount struct{}
+
+func (m *modCount) inc() {}
+
+type iterMods struct{}
+
+func (im *iterMods) sync(m *modCount) {}
+
+func (im *iterMods) check(m *modCount) {}
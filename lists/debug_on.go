@@ -0,0 +1,31 @@
+//go:build gcldebug
+
+package lists
+
+// modCount counts the structural modifications of a list, i.e. insertions,
+// deletions and relinking of nodes. Changing values in place is not a
+// structural modification.
+type modCount struct {
+	n uint64
+}
+
+func (m *modCount) inc() {
+	m.n++
+}
+
+// iterMods is the modification count of a list as seen by an iterator. It is
+// recorded when the iterator is created and whenever the iterator modifies
+// the list itself.
+type iterMods struct {
+	n uint64
+}
+
+func (im *iterMods) sync(m *modCount) {
+	im.n = m.n
+}
+
+func (im *iterMods) check(m *modCount) {
+	if im.n != m.n {
+		panic("lists: iterator used after its list was modified")
+	}
+}
@@ -0,0 +1,79 @@
+//go:build gcldebug
+
+package lists
+
+import (
+	"testing"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/iters"
+)
+
+func TestStaleIterator(t *testing.T) {
+	l := New(1, 2, 3)
+	it1, it2 := IterMut(l), Iter(l)
+	it1.Next()
+	it1.Delete()
+	mustPanic(t, "using an iterator after another one deleted must panic", func() { it2.Next() })
+
+	if v := *it1.Next(); v != 2 {
+		t.Errorf("it1.Next() = %v after its own Delete, want = 2", v)
+	}
+	it1.Insert(4)
+	if *it1.Next() != 4 {
+		t.Error("an iterator must stay usable after its own Insert")
+	}
+
+	rit := RIter(l)
+	PushBack(l, 5)
+	mustPanic(t, "using an iterator after PushBack must panic", func() { rit.Next() })
+
+	it := Iter(l)
+	Sort(l)
+	mustPanic(t, "using an iterator after Sort must panic", func() { it.Next() })
+
+	it = Iter(l)
+	Reverse(l)
+	it.Next()
+}
+
+func TestStaleIteratorSplice(t *testing.T) {
+	l1, l2 := New(1, 2), New(3, 4)
+	dst, from, to, other := IterMut(l1), IterMut(l2), IterMut(l2), Iter(l2)
+	to.Next()
+	Splice(dst, l2, from, to)
+	dst.Next()
+	from.Next()
+	to.Next()
+	mustPanic(t, "using an iterator of src after Splice must panic", func() { other.Next() })
+}
+
+func TestStaleIteratorCompact(t *testing.T) {
+	l := New(1, 1, 2, 2, 3)
+	it := Iter(l)
+	iters.Advance[int](it, 4)
+	Compact(l)
+	mustPanic(t, "using an iterator after Compact must panic", func() { it.Next() })
+
+	l = New(1, 1, 2)
+	it = Iter(l)
+	CompactFunc(l, gcl.Equal[int])
+	mustPanic(t, "using an iterator after CompactFunc must panic", func() { it.Next() })
+
+	it = Iter(l)
+	Compact(l)
+	it.Next()
+}
+
+func TestStaleIteratorRemaining(t *testing.T) {
+	l := New(1, 2, 3)
+	it, rit := IterMut(l), RIter(l)
+	it.Next()
+	PushFront(l, 0)
+	mustPanic(t, "Remaining after PushFront must panic", func() { it.Remaining() })
+	mustPanic(t, "Remaining of a reverse iterator after PushFront must panic", func() { rit.Remaining() })
+	mustPanic(t, "SplitAt with a stale iterator must panic", func() { SplitAt(it) })
+	if Len(l) != 4 {
+		t.Errorf("Len(l) = %v, want = 4", Len(l))
+	}
+}
@@ -0,0 +1,134 @@
+package lists
+
+// Element is a handle to an element of a list. Unlike iterators, an element
+// handle never moves, so it can be kept to access or reorder its element
+// later. Element handles are returned by PushBackElem, PushFrontElem and
+// InsertAfter. A handle follows its element when the element is moved to
+// another list by Splice, Concat, SplitAt, MergeFunc or Partition. Functions
+// taking a handle and a list panic if the element is not in that list, for
+// example because it was removed.
+type Element[T any] struct {
+	node *node[T]
+}
+
+// PushBackElem appends v to the back of list l and returns a handle to it.
+// This function is O(1).
+func PushBackElem[T any](l *List[T], v T) *Element[T] {
+	l.elems = true
+	n := &node[T]{value: v}
+	l.insertBetween(n, l.tail.prev, l.tail)
+	return &Element[T]{node: n}
+}
+
+// PushFrontElem inserts v at the beginning of list l and returns a handle to
+// it.
+// This function is O(1).
+func PushFrontElem[T any](l *List[T], v T) *Element[T] {
+	l.elems = true
+	n := &node[T]{value: v}
+	l.insertBetween(n, l.head, l.head.next)
+	return &Element[T]{node: n}
+}
+
+// InsertAfter inserts v next after element mark of list l and returns a
+// handle to it. mark must be an element of l, otherwise InsertAfter panics.
+// This function is O(1).
+func InsertAfter[T any](l *List[T], mark *Element[T], v T) *Element[T] {
+	mark.check(l)
+	n := &node[T]{value: v}
+	l.insertBetween(n, mark.node, mark.node.next)
+	return &Element[T]{node: n}
+}
+
+func (e *Element[T]) check(l *List[T]) {
+	require(e.node.lst == l, "element does not belong to the list")
+}
+
+// Value returns the value of element e. This function is O(1).
+func (e *Element[T]) Value() T {
+	return e.node.value
+}
+
+// Set sets the value of element e to v. This function is O(1).
+func (e *Element[T]) Set(v T) {
+	e.node.value = v
+}
+
+// Remove removes element e from list l and returns its value. e must be an
+// element of l, otherwise Remove panics. After the call, e is no longer
+// valid.
+// This function is O(1).
+func (e *Element[T]) Remove(l *List[T]) T {
+	e.check(l)
+	l.deleteNode(e.node)
+	return e.node.value
+}
+
+// MoveToFront moves element e to the beginning of list l. e must be an
+// element of l, otherwise MoveToFront panics.
+// This function is O(1).
+func (e *Element[T]) MoveToFront(l *List[T]) {
+	e.check(l)
+	e.moveAfter(l, l.head)
+}
+
+// MoveToBack moves element e to the back of list l. e must be an element of
+// l, otherwise MoveToBack panics.
+// This function is O(1).
+func (e *Element[T]) MoveToBack(l *List[T]) {
+	e.check(l)
+	e.moveAfter(l, l.tail.prev)
+}
+
+// MoveBefore moves element e to its new position right before element mark.
+// Both e and mark must be elements of list l, otherwise MoveBefore panics.
+// This function is O(1).
+func (e *Element[T]) MoveBefore(l *List[T], mark *Element[T]) {
+	e.check(l)
+	mark.check(l)
+	if e == mark {
+		return
+	}
+	e.moveAfter(l, mark.node.prev)
+}
+
+// MoveAfter moves element e to its new position right after element mark.
+// Both e and mark must be elements of list l, otherwise MoveAfter panics.
+// This function is O(1).
+func (e *Element[T]) MoveAfter(l *List[T], mark *Element[T]) {
+	e.check(l)
+	mark.check(l)
+	if e == mark {
+		return
+	}
+	e.moveAfter(l, mark.node)
+}
+
+// IterMut returns a mutable forward iterator of list l located at element e.
+// So the first call to Next returns the element after e. e must be an
+// element of l, otherwise IterMut panics.
+// This function is O(i), where i is the position of e in the list, since the
+// iterator needs to know its position.
+func (e *Element[T]) IterMut(l *List[T]) *FrwIterMut[T] {
+	e.check(l)
+	index := -1
+	for n := e.node; n != l.head; n = n.prev {
+		index += 1
+	}
+	it := &FrwIterMut[T]{
+		node:  e.node,
+		lst:   l,
+		index: index,
+	}
+	it.mods.sync(&l.mods)
+	return it
+}
+
+// moveAfter relinks the node of e next after the given node of list l.
+func (e *Element[T]) moveAfter(l *List[T], prev *node[T]) {
+	if prev == e.node || prev.next == e.node {
+		return
+	}
+	l.unlink(e.node, e.node, 1)
+	l.linkAfter(prev, e.node, e.node, 1)
+}
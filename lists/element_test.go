@@ -0,0 +1,178 @@
+package lists
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shayanh/gcl/iters"
+)
+
+func mustPanic(t *testing.T, msg string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Error(msg)
+		}
+	}()
+	fn()
+}
+
+func TestElementPushInsert(t *testing.T) {
+	l := New[int]()
+	e2 := PushBackElem(l, 2)
+	e1 := PushFrontElem(l, 1)
+	e3 := InsertAfter(l, e2, 3)
+	if want := New(1, 2, 3); !Equal(l, want) || Len(l) != 3 {
+		t.Errorf("got %v, want %v", l, want)
+	}
+	if e1.Value() != 1 || e2.Value() != 2 || e3.Value() != 3 {
+		t.Error("handles must refer to the pushed values")
+	}
+	e2.Set(20)
+	if want := New(1, 20, 3); !Equal(l, want) {
+		t.Errorf("got %v, want %v", l, want)
+	}
+}
+
+func TestElementMove(t *testing.T) {
+	l := New[int]()
+	var es []*Element[int]
+	for i := 0; i < 5; i++ {
+		es = append(es, PushBackElem(l, i))
+	}
+	es[4].MoveToFront(l)
+	es[0].MoveToBack(l)
+	es[2].MoveBefore(l, es[1])
+	es[3].MoveAfter(l, es[4])
+	es[3].MoveAfter(l, es[3])
+	want := New(4, 3, 2, 1, 0)
+	if !Equal(l, want) || Len(l) != 5 {
+		t.Errorf("got %v, want %v", l, want)
+	}
+	if !iters.Equal[int](RIter(l), RIter(want)) {
+		t.Errorf("RIter(%v) is broken after moves", l)
+	}
+}
+
+func TestElementRemove(t *testing.T) {
+	l := New(1)
+	e := PushBackElem(l, 2)
+	PushBack(l, 3)
+	if v := e.Remove(l); v != 2 || !Equal(l, New(1, 3)) {
+		t.Errorf("e.Remove(l) = %v, got %v, want 2, [1 3]", v, l)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("using a removed element must panic")
+		}
+	}()
+	e.MoveToFront(l)
+}
+
+func TestElementForeignList(t *testing.T) {
+	l1, l2 := New(1), New(2)
+	e1, e2 := PushBackElem(l1, 10), PushBackElem(l2, 20)
+	for _, fn := range []func(){
+		func() { e1.Remove(l2) },
+		func() { e1.MoveToBack(l2) },
+		func() { e2.MoveBefore(l2, e1) },
+		func() { InsertAfter(l2, e1, 3) },
+		func() { e1.IterMut(l2) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("using an element of another list must panic")
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestElementIterMut(t *testing.T) {
+	l := New(1, 2)
+	e := PushBackElem(l, 3)
+	PushBack(l, 4, 5)
+	it := e.IterMut(l)
+	if it.Remaining() != 2 || *it.Next() != 4 || !it.HasPrev() || *it.Prev() != 3 {
+		t.Error("wrong iterator obtained from element")
+	}
+	it.Insert(30)
+	if want := New(1, 2, 3, 30, 4, 5); !Equal(l, want) {
+		t.Errorf("got %v, want %v", l, want)
+	}
+}
+
+func TestElementDeletedElsewhere(t *testing.T) {
+	l := New[int]()
+	e := PushBackElem(l, 1)
+	PushBack(l, 2)
+	PopFront(l)
+	mustPanic(t, "removing an element deleted by PopFront must panic", func() { e.Remove(l) })
+	if !Equal(l, New(2)) || Len(l) != 1 {
+		t.Errorf("got %v with length %v, want [2]", l, Len(l))
+	}
+
+	e = PushBackElem(l, 2)
+	Compact(l)
+	mustPanic(t, "removing an element deleted by Compact must panic", func() { e.Remove(l) })
+
+	e = PushBackElem(l, 3)
+	e.Remove(l)
+	mustPanic(t, "removing an element twice must panic", func() { e.Remove(l) })
+	if Len(l) != 1 {
+		t.Errorf("Len(l) = %v, want = 1", Len(l))
+	}
+}
+
+func TestElementMovedList(t *testing.T) {
+	l1, l2 := New(1), New[int]()
+	e := PushBackElem(l2, 2)
+	Concat(l1, l2)
+	mustPanic(t, "using an element with its old list after Concat must panic", func() { e.Remove(l2) })
+	if Len(l1) != 2 || Len(l2) != 0 {
+		t.Errorf("Len(l1), Len(l2) = %v, %v, want = 2, 0", Len(l1), Len(l2))
+	}
+	e.MoveToFront(l1)
+	if want := New(2, 1); !Equal(l1, want) {
+		t.Errorf("got %v, want %v", l1, want)
+	}
+
+	rest := SplitAt(IterMut(l1))
+	mustPanic(t, "using an element with its old list after SplitAt must panic", func() { e.MoveToBack(l1) })
+	if v := e.Remove(rest); v != 2 || !Equal(rest, New(1)) || Len(rest) != 1 {
+		t.Errorf("e.Remove(rest) = %v, got %v, want 2, [1]", v, rest)
+	}
+
+	l1, l2 = New(1, 3), New[int]()
+	e = PushBackElem(l2, 2)
+	Merge(l1, l2)
+	e.MoveToBack(l1)
+	if want := New(1, 3, 2); !Equal(l1, want) {
+		t.Errorf("got %v, want %v", l1, want)
+	}
+	even, _ := Partition(l1, func(v int) bool { return v%2 == 0 })
+	mustPanic(t, "using an element with its old list after Partition must panic", func() { e.Remove(l1) })
+	e.Remove(even)
+	if Len(even) != 0 {
+		t.Errorf("Len(even) = %v, want = 0", Len(even))
+	}
+}
+
+func TestElementAfterUnmarshal(t *testing.T) {
+	l := New(1)
+	e := PushBackElem(l, 2)
+	if err := json.Unmarshal([]byte("[5, 6, 7]"), l); err != nil {
+		t.Fatal(err)
+	}
+	mustPanic(t, "using an element after its list was unmarshaled must panic", func() { e.Remove(l) })
+	if !Equal(l, New(5, 6, 7)) || Len(l) != 3 {
+		t.Errorf("got %v with length %v, want [5 6 7]", l, Len(l))
+	}
+
+	var zero List[int]
+	if err := json.Unmarshal([]byte("[1]"), &zero); err != nil || !Equal(&zero, New(1)) {
+		t.Errorf("unmarshaling into a zero list got %v, %v, want [1]", &zero, err)
+	}
+}
@@ -8,8 +8,10 @@ func require(check bool, failMsg string) {
 
 // FrwIter is a list forward iterator.
 type FrwIter[T any] struct {
-	node *node[T]
-	lst  *List[T]
+	mods  iterMods
+	node  *node[T]
+	lst   *List[T]
+	index int
 }
 
 func (it *FrwIter[T]) HasNext() bool {
@@ -21,17 +23,46 @@ func (it *FrwIter[T]) HasNext() bool {
 
 func (it *FrwIter[T]) Next() T {
 	require(it.HasNext(), "iterator must have next")
+	it.mods.check(&it.lst.mods)
 
 	it.node = it.node.next
+	it.index += 1
 	return it.node.value
 }
 
+func (it *FrwIter[T]) HasPrev() bool {
+	if it.node == nil || it.node == it.lst.head {
+		return false
+	}
+	return it.node.prev != it.lst.head
+}
+
+func (it *FrwIter[T]) Prev() T {
+	require(it.HasPrev(), "iterator must have prev")
+	it.mods.check(&it.lst.mods)
+
+	it.node = it.node.prev
+	it.index -= 1
+	return it.node.value
+}
+
+// Remaining returns the number of elements that are left to iterate. The
+// result is only correct if the list was not modified by anything other than
+// the iterator since the iterator was created.
+// This function is O(1).
+func (it *FrwIter[T]) Remaining() int {
+	it.mods.check(&it.lst.mods)
+	return it.lst.size - it.index - 1
+}
+
 // FrwIterMut is a mutable forward iterator for lists. It allows mutations by
 // returning pointers to the list elements. FrwIterMut is an iterator over
 // pointers of type T. In other words, FrwIterMut[T] implements iters.Iterator[*T].
 type FrwIterMut[T any] struct {
-	node *node[T]
-	lst  *List[T]
+	mods  iterMods
+	node  *node[T]
+	lst   *List[T]
+	index int
 }
 
 func (it *FrwIterMut[T]) HasNext() bool {
@@ -43,40 +74,105 @@ func (it *FrwIterMut[T]) HasNext() bool {
 
 func (it *FrwIterMut[T]) Next() *T {
 	require(it.HasNext(), "iterator must have next")
+	it.mods.check(&it.lst.mods)
 
 	it.node = it.node.next
+	it.index += 1
 	return &it.node.value
 }
 
+func (it *FrwIterMut[T]) HasPrev() bool {
+	if it.node == nil || it.node == it.lst.head {
+		return false
+	}
+	return it.node.prev != it.lst.head
+}
+
+func (it *FrwIterMut[T]) Prev() *T {
+	require(it.HasPrev(), "iterator must have prev")
+	it.mods.check(&it.lst.mods)
+
+	it.node = it.node.prev
+	it.index -= 1
+	return &it.node.value
+}
+
+// Remaining returns the number of elements that are left to iterate. The
+// result is only correct if the list was not modified by anything other than
+// the iterator since the iterator was created.
+// This function is O(1).
+func (it *FrwIterMut[T]) Remaining() int {
+	it.mods.check(&it.lst.mods)
+	return it.lst.size - it.index - 1
+}
+
 // Insert inserts the given values next after the iterator it. This function is
 // O(len(elems)). So inserting a single element would be O(1).
 func (it *FrwIterMut[T]) Insert(elems ...T) {
+	it.mods.check(&it.lst.mods)
 	require(it.node.next != nil, "bad iterator")
 	for i := len(elems) - 1; i >= 0; i-- {
 		elem := elems[i]
 		node := &node[T]{value: elem}
 		it.lst.insertBetween(node, it.node, it.node.next)
 	}
+	it.mods.sync(&it.lst.mods)
 }
 
 // Delete deletes the element that the iterator it is pointing to. Delete
 // requires the iterator it to point to an actual element in the list. For
 // example, it's not possible to call Delete on the IterMut iterator (in its
 // inital state) because this iterator is located at one step before the first
-// element and this is not an actual list element. This function is O(1).
+// element and this is not an actual list element.
+// After Delete, the iterator is located at the element before the deleted
+// one, or at one-before-first if the first element is deleted. So the next
+// call to Next returns the element after the deleted one, and the following
+// loop deletes all elements that match the `bad` predicate:
+//
+//	for it.HasNext() {
+//		if bad(*it.Next()) {
+//			it.Delete()
+//		}
+//	}
+//
+// This function is O(1).
 func (it *FrwIterMut[T]) Delete() {
+	it.mods.check(&it.lst.mods)
 	require(it.node.prev != nil && it.node.next != nil, "bad iterator")
 	prev, _ := it.lst.deleteNode(it.node)
-	it = &FrwIterMut[T]{
-		node: prev,
-		lst:  it.lst,
-	}
+	it.node = prev
+	it.index -= 1
+	it.mods.sync(&it.lst.mods)
+}
+
+// DeleteAndNext deletes the element that the iterator it is pointing to,
+// moves the iterator to the next element and returns a pointer to it. It
+// requires the iterator to have a next element, otherwise it panics without
+// deleting anything.
+// This function is O(1).
+func (it *FrwIterMut[T]) DeleteAndNext() *T {
+	require(it.HasNext(), "iterator must have next")
+	it.Delete()
+	return it.Next()
+}
+
+// DeleteAndPrev deletes the element that the iterator it is pointing to,
+// moves the iterator to the previous element and returns a pointer to it. It
+// requires the iterator to have a previous element, otherwise it panics
+// without deleting anything.
+// This function is O(1).
+func (it *FrwIterMut[T]) DeleteAndPrev() *T {
+	require(it.HasPrev(), "iterator must have prev")
+	it.Delete()
+	return &it.node.value
 }
 
 // RevIter is a list reverse iterator.
 type RevIter[T any] struct {
-	node *node[T]
-	lst  *List[T]
+	mods  iterMods
+	node  *node[T]
+	lst   *List[T]
+	index int
 }
 
 func (it *RevIter[T]) HasNext() bool {
@@ -88,17 +184,46 @@ func (it *RevIter[T]) HasNext() bool {
 
 func (it *RevIter[T]) Next() T {
 	require(it.HasNext(), "iterator must have next")
+	it.mods.check(&it.lst.mods)
 
 	it.node = it.node.prev
+	it.index -= 1
+	return it.node.value
+}
+
+func (it *RevIter[T]) HasPrev() bool {
+	if it.node == nil || it.node == it.lst.tail {
+		return false
+	}
+	return it.node.next != it.lst.tail
+}
+
+func (it *RevIter[T]) Prev() T {
+	require(it.HasPrev(), "iterator must have prev")
+	it.mods.check(&it.lst.mods)
+
+	it.node = it.node.next
+	it.index += 1
 	return it.node.value
 }
 
+// Remaining returns the number of elements that are left to iterate. The
+// result is only correct if the list was not modified by anything other than
+// the iterator since the iterator was created.
+// This function is O(1).
+func (it *RevIter[T]) Remaining() int {
+	it.mods.check(&it.lst.mods)
+	return it.index
+}
+
 // RevIterMut is a mutable reverse iterator for lists. It allows mutations by
 // returning pointers to the list elements. RevIterMut is an iterator over
 // pointers of type T. In other words, RevIterMut[T] implements iters.Iterator[*T].
 type RevIterMut[T any] struct {
-	node *node[T]
-	lst  *List[T]
+	mods  iterMods
+	node  *node[T]
+	lst   *List[T]
+	index int
 }
 
 func (it *RevIterMut[T]) HasNext() bool {
@@ -110,33 +235,114 @@ func (it *RevIterMut[T]) HasNext() bool {
 
 func (it *RevIterMut[T]) Next() *T {
 	require(it.HasNext(), "iterator must have next")
+	it.mods.check(&it.lst.mods)
 
 	it.node = it.node.prev
+	it.index -= 1
 	return &it.node.value
 }
 
+func (it *RevIterMut[T]) HasPrev() bool {
+	if it.node == nil || it.node == it.lst.tail {
+		return false
+	}
+	return it.node.next != it.lst.tail
+}
+
+func (it *RevIterMut[T]) Prev() *T {
+	require(it.HasPrev(), "iterator must have prev")
+	it.mods.check(&it.lst.mods)
+
+	it.node = it.node.next
+	it.index += 1
+	return &it.node.value
+}
+
+// Remaining returns the number of elements that are left to iterate. The
+// result is only correct if the list was not modified by anything other than
+// the iterator since the iterator was created.
+// This function is O(1).
+func (it *RevIterMut[T]) Remaining() int {
+	it.mods.check(&it.lst.mods)
+	return it.index
+}
+
 // Insert inserts the given values next after (in a reverse direction) the
 // iterator it. This function is O(len(elems)). So inserting a single element
 // would be O(1).
 func (it *RevIterMut[T]) Insert(elems ...T) {
+	it.mods.check(&it.lst.mods)
 	require(it.node.prev != nil, "bad iterator")
 	for _, elem := range elems {
 		node := &node[T]{value: elem}
 		it.lst.insertBetween(node, it.node.prev, it.node)
 	}
+	it.index += len(elems)
+	it.mods.sync(&it.lst.mods)
 }
 
 // Delete deletes the element that the iterator it is pointing to. Delete
 // requires the iterator it to point to an actual element in the list. For
 // example, it's not possible to call Delete on the RIterMut iterator (in its
 // inital state) because this iterator is located at one step past the last
-// element and this is not an actual list element. This function is O(1).
+// element and this is not an actual list element.
+// After Delete, the iterator is located at the element after the deleted one
+// in the list order, or at one-past-last if the last element is deleted. So
+// the next call to Next returns the element before the deleted one.
+// This function is O(1).
 func (it *RevIterMut[T]) Delete() {
+	it.mods.check(&it.lst.mods)
 	require(it.node.prev != nil && it.node.next != nil,
 		"bad iterator")
 	_, next := it.lst.deleteNode(it.node)
-	it = &RevIterMut[T]{
-		node: next,
-		lst:  it.lst,
-	}
+	it.node = next
+	it.mods.sync(&it.lst.mods)
+}
+
+// DeleteAndNext deletes the element that the iterator it is pointing to,
+// moves the iterator to the next element (in a reverse direction) and returns
+// a pointer to it. It requires the iterator to have a next element, otherwise
+// it panics without deleting anything.
+// This function is O(1).
+func (it *RevIterMut[T]) DeleteAndNext() *T {
+	require(it.HasNext(), "iterator must have next")
+	it.Delete()
+	return it.Next()
+}
+
+// DeleteAndPrev deletes the element that the iterator it is pointing to,
+// moves the iterator to the previous element (in a reverse direction) and
+// returns a pointer to it. It requires the iterator to have a previous
+// element, otherwise it panics without deleting anything.
+// This function is O(1).
+func (it *RevIterMut[T]) DeleteAndPrev() *T {
+	require(it.HasPrev(), "iterator must have prev")
+	it.Delete()
+	return &it.node.value
+}
+
+// SliceIter is a forward iterator over a range of list elements. It is
+// returned by Slice.
+type SliceIter[T any] struct {
+	mods      iterMods
+	node      *node[T]
+	lst       *List[T]
+	remaining int
+}
+
+func (it *SliceIter[T]) HasNext() bool {
+	return it.remaining > 0
+}
+
+func (it *SliceIter[T]) Next() T {
+	require(it.HasNext(), "iterator must have next")
+	it.mods.check(&it.lst.mods)
+
+	it.node = it.node.next
+	it.remaining -= 1
+	return it.node.value
+}
+
+func (it *SliceIter[T]) Remaining() int {
+	return it.remaining
 }
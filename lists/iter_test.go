@@ -173,3 +173,100 @@ func TestRevIterMutDelete(t *testing.T) {
 		}
 	}
 }
+
+func TestIterPrev(t *testing.T) {
+	l := New(1, 2, 3, 4)
+	it := Iter(l)
+	if it.HasPrev() || it.Remaining() != 4 {
+		t.Errorf("it.HasPrev(), it.Remaining() = %v, %v, want = false, 4", it.HasPrev(), it.Remaining())
+	}
+	iters.Advance[int](it, 3)
+	if !it.HasPrev() || it.Prev() != 2 || it.Remaining() != 2 {
+		t.Error("wrong FrwIter Prev behavior")
+	}
+
+	rit := RIter(l)
+	iters.Advance[int](rit, 3)
+	if rit.Remaining() != 1 || !rit.HasPrev() || rit.Prev() != 3 || rit.Remaining() != 2 {
+		t.Error("wrong RevIter Prev behavior")
+	}
+}
+
+func TestIterMutRemaining(t *testing.T) {
+	l := New(1, 2, 3, 4)
+	it := IterMut(l)
+	iters.Advance[*int](it, 2)
+	it.Delete()
+	if it.Remaining() != 2 || *it.Next() != 3 {
+		t.Errorf("it.Remaining() = %v after Delete, want = 2", it.Remaining())
+	}
+	it.Insert(5, 6)
+	if it.Remaining() != 3 {
+		t.Errorf("it.Remaining() = %v after Insert, want = 3", it.Remaining())
+	}
+
+	rit := RIterMut(l)
+	rit.Next()
+	rit.Insert(7)
+	if rit.Remaining() != 5 || *rit.Next() != 7 {
+		t.Errorf("rit.Remaining() = %v after Insert, want = 5", rit.Remaining())
+	}
+}
+
+func TestDeleteLoop(t *testing.T) {
+	l := New(1, 2, 2, 3, 4, 4)
+	it := IterMut(l)
+	for it.HasNext() {
+		if *it.Next()%2 == 0 {
+			it.Delete()
+		}
+	}
+	if want := New(1, 3); !Equal(l, want) || Len(l) != 2 {
+		t.Errorf("got %v, want %v", l, want)
+	}
+
+	l = New(1, 2, 2, 3, 4, 4)
+	rit := RIterMut(l)
+	for rit.HasNext() {
+		if *rit.Next()%2 == 0 {
+			rit.Delete()
+		}
+	}
+	if want := New(1, 3); !Equal(l, want) || Len(l) != 2 {
+		t.Errorf("got %v, want %v", l, want)
+	}
+	if rit.Remaining() != 0 {
+		t.Errorf("rit.Remaining() = %v, want = 0", rit.Remaining())
+	}
+}
+
+func TestDeleteAndNext(t *testing.T) {
+	l := New(1, 2, 3, 4)
+	it := IterMut(l)
+	it.Next()
+	if v := *it.DeleteAndNext(); v != 2 || it.Remaining() != 2 {
+		t.Errorf("it.DeleteAndNext() = %v, want = 2", v)
+	}
+	it.Next()
+	if v := *it.DeleteAndPrev(); v != 2 || it.Remaining() != 1 {
+		t.Errorf("it.DeleteAndPrev() = %v, want = 2", v)
+	}
+	if want := New(2, 4); !Equal(l, want) {
+		t.Errorf("got %v, want %v", l, want)
+	}
+
+	rit := RIterMut(l)
+	rit.Next()
+	if v := *rit.DeleteAndNext(); v != 2 || rit.Remaining() != 0 {
+		t.Errorf("rit.DeleteAndNext() = %v, want = 2", v)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("DeleteAndNext without a next element must panic")
+		}
+		if want := New(2); !Equal(l, want) {
+			t.Errorf("a failed DeleteAndNext must not delete, got %v, want %v", l, want)
+		}
+	}()
+	rit.DeleteAndNext()
+}
@@ -1,5 +1,19 @@
 // Package lists provides a doubly linked list and various functions useful
 // with lists of any type.
+//
+// A list is not safe for concurrent use. Package syncs provides a list that
+// is guarded by a lock.
+//
+// An iterator knows its position in the list, which makes Remaining O(1). A
+// structural modification of the list (inserting, deleting or relinking
+// elements) by anything other than the iterator itself invalidates the
+// iterator: it may still walk the list, but its position, and so Remaining
+// and iters.Len, are no longer correct.
+//
+// When built with the gcldebug build tag, every list counts its structural
+// modifications, and an iterator panics if it is used after its list was
+// modified by anything other than the iterator itself. Without the tag, these
+// checks cost nothing.
 package lists
 
 import (
@@ -11,20 +25,28 @@ import (
 	"github.com/shayanh/gcl/internal"
 	"github.com/shayanh/gcl/iters"
 	"golang.org/x/exp/constraints"
-	"golang.org/x/exp/slices"
 )
 
 type node[T any] struct {
 	value T
 	next  *node[T]
 	prev  *node[T]
+	// lst is the list that the node belongs to, or nil if it was deleted. It
+	// is kept up to date for nodes that may have an Element handle, see
+	// List.elems.
+	lst *List[T]
 }
 
 // List is doubly linked list.
 type List[T any] struct {
+	mods modCount
 	head *node[T]
 	tail *node[T]
 	size int
+	// elems is set once an Element handle is created for a node of the list.
+	// Moving nodes out of such a list updates their lst field, which makes
+	// it O(n) instead of O(1) for n moved nodes.
+	elems bool
 }
 
 // New creates a new linked list and returns a pointer to it.
@@ -76,7 +98,15 @@ func (l *List[T]) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &slice); err != nil {
 		return err
 	}
-	*l = *New(slice...)
+	// The list is rebuilt in place, so that element handles and iterators of
+	// the old content see their nodes deleted.
+	if l.head == nil {
+		*l = *New[T]()
+	}
+	for l.size > 0 {
+		l.deleteNode(l.head.next)
+	}
+	PushBack(l, slice...)
 	return nil
 }
 
@@ -88,40 +118,84 @@ func Len[T any](l *List[T]) int {
 // Iter returns an forward iterator to the beginning. Initially, the returned
 // iterator is located at one step before the first element (one-before-first).
 func Iter[T any](l *List[T]) *FrwIter[T] {
-	return &FrwIter[T]{
-		node: l.head,
-		lst:  l,
+	it := &FrwIter[T]{
+		node:  l.head,
+		lst:   l,
+		index: -1,
 	}
+	it.mods.sync(&l.mods)
+	return it
 }
 
 // IterMut returns a forward iterator to the beginning with mutable pointers.
 // Initially, the returned iterator is located at one step before the first
 // element (one-before-first).
 func IterMut[T any](l *List[T]) *FrwIterMut[T] {
-	return &FrwIterMut[T]{
-		node: l.head,
-		lst:  l,
+	it := &FrwIterMut[T]{
+		node:  l.head,
+		lst:   l,
+		index: -1,
 	}
+	it.mods.sync(&l.mods)
+	return it
 }
 
 // RIter returns a reverse iterator going from the end to the beginning.
 // Initially, the returned iterator is located at one step past the last element
 // (one-past-last).
 func RIter[T any](l *List[T]) *RevIter[T] {
-	return &RevIter[T]{
-		node: l.tail,
-		lst:  l,
+	it := &RevIter[T]{
+		node:  l.tail,
+		lst:   l,
+		index: l.size,
 	}
+	it.mods.sync(&l.mods)
+	return it
 }
 
 // RIterMut returns a reverse iterator going from the end to the beginning with
 // mutable pointers. Initially, the returned iterator is located at one step
 // past the last element (one-past-last).
 func RIterMut[T any](l *List[T]) *RevIterMut[T] {
-	return &RevIterMut[T]{
-		node: l.tail,
-		lst:  l,
-	}
+	it := &RevIterMut[T]{
+		node:  l.tail,
+		lst:   l,
+		index: l.size,
+	}
+	it.mods.sync(&l.mods)
+	return it
+}
+
+// IterAt returns a mutable forward iterator located at the i-th element of the
+// list. So the first call to Next returns the element after it. It requires
+// -1 <= i < Len(l), otherwise it panics. IterAt(l, -1) is the same as
+// IterMut(l).
+// This function is O(min(i, n-i)), where n is length of the list.
+func IterAt[T any](l *List[T], i int) *FrwIterMut[T] {
+	require(-1 <= i && i < l.size, "index out of range")
+	it := &FrwIterMut[T]{
+		node:  l.nodeAt(i),
+		lst:   l,
+		index: i,
+	}
+	it.mods.sync(&l.mods)
+	return it
+}
+
+// Slice returns an iterator over the elements of the list in the half-open
+// range [from, to). It requires 0 <= from <= to <= Len(l), otherwise it
+// panics. The elements are not copied. Like other iterators, the returned
+// iterator becomes invalid if the list is modified.
+// This function is O(min(from, n-from)), where n is length of the list.
+func Slice[T any](l *List[T], from, to int) *SliceIter[T] {
+	require(0 <= from && from <= to && to <= l.size, "index out of range")
+	it := &SliceIter[T]{
+		node:      l.nodeAt(from - 1),
+		lst:       l,
+		remaining: to - from,
+	}
+	it.mods.sync(&l.mods)
+	return it
 }
 
 // Equal tests whether two lists are equal: the same length and all elements
@@ -262,20 +336,182 @@ func Back[T any](l *List[T]) T {
 	return l.tail.prev.value
 }
 
+// At returns the i-th element of the list. It requires 0 <= i < Len(l),
+// otherwise it panics.
+// This function is O(min(i, n-i)), where n is length of the list.
+func At[T any](l *List[T], i int) T {
+	require(0 <= i && i < l.size, "index out of range")
+	return l.nodeAt(i).value
+}
+
+// Set sets the i-th element of the list to v. It requires 0 <= i < Len(l),
+// otherwise it panics.
+// This function is O(min(i, n-i)), where n is length of the list.
+func Set[T any](l *List[T], i int, v T) {
+	require(0 <= i && i < l.size, "index out of range")
+	l.nodeAt(i).value = v
+}
+
+// InsertAt inserts the given elements into the list such that the first one
+// is at index i. It requires 0 <= i <= Len(l), otherwise it panics.
+// This function is O(min(i, n-i) + len(elems)), where n is length of the list.
+func InsertAt[T any](l *List[T], i int, elems ...T) {
+	require(0 <= i && i <= l.size, "index out of range")
+	prev := l.nodeAt(i - 1)
+	for _, elem := range elems {
+		node := &node[T]{value: elem}
+		l.insertBetween(node, prev, prev.next)
+		prev = node
+	}
+}
+
+// DeleteAt deletes the i-th element of the list. It requires 0 <= i < Len(l),
+// otherwise it panics.
+// This function is O(min(i, n-i)), where n is length of the list.
+func DeleteAt[T any](l *List[T], i int) {
+	require(0 <= i && i < l.size, "index out of range")
+	l.deleteNode(l.nodeAt(i))
+}
+
 // Reverse reverses the elements of the given list.
 // This function is O(n), where n is length of the list.
 func Reverse[T any](l *List[T]) {
-	internal.Reverse[T](IterMut(l), RIterMut(l), l.size)
+	internal.Reverse[T](IterMut(l), RIterMut(l))
+}
+
+// Splice moves elements of list src to the list of iterator dst and inserts
+// them next after dst. The moved elements are the ones after the position of
+// iterator `from` up to and including the element that iterator `to` is
+// located at. Both `from` and `to` must be iterators of src, and `from` cannot
+// be located after `to`. If they are at the same position, nothing is moved.
+// src can be the list of dst as long as dst is not located inside the moved
+// range.
+// The nodes are relinked, so pointers returned by mutable iterators keep
+// referring to the moved elements. After the call, dst and `from` keep their
+// positions and `to` is located at the last moved element in its new list.
+// This function is O(n), where n is the number of moved elements. The nodes
+// are relinked in O(1), but they are counted to keep the list sizes right.
+func Splice[T any](dst *FrwIterMut[T], src *List[T], from, to *FrwIterMut[T]) {
+	dst.mods.check(&dst.lst.mods)
+	from.mods.check(&from.lst.mods)
+	to.mods.check(&to.lst.mods)
+	require(dst.node.next != nil, "bad iterator")
+	require(from.lst == src && to.lst == src, "iterators must belong to src")
+	n := 0
+	for node := from.node; node != to.node; n++ {
+		node = node.next
+		require(node != src.tail, "from cannot be located after to")
+		require(node == to.node || node != dst.node, "dst cannot be inside the moved range")
+	}
+	if n == 0 || dst.node == from.node || dst.node == to.node {
+		return
+	}
+	first, last := from.node.next, to.node
+	src.unlink(first, last, n)
+	dst.lst.linkAfter(dst.node, first, last, n)
+	dst.lst.adopt(src, first, last)
+
+	if dst.lst == src && dst.index > to.index {
+		dst.index -= n
+	}
+	if dst.lst == src && dst.index < from.index {
+		from.index += n
+	}
+	to.lst = dst.lst
+	to.index = dst.index + n
+	dst.mods.sync(&dst.lst.mods)
+	from.mods.sync(&src.mods)
+	to.mods.sync(&to.lst.mods)
+}
+
+// Concat moves all elements of l2 to the end of l1, leaving l2 empty. l1 and
+// l2 must be different lists.
+// This function is O(1), or O(n) if element handles were created for l2,
+// where n is length of l2.
+func Concat[T any](l1, l2 *List[T]) {
+	require(l1 != l2, "cannot concat a list with itself")
+	if l2.size == 0 {
+		return
+	}
+	first, last, n := l2.head.next, l2.tail.prev, l2.size
+	l2.unlink(first, last, n)
+	l1.linkAfter(l1.tail.prev, first, last, n)
+	l1.adopt(l2, first, last)
+}
+
+// SplitAt cuts the list of iterator it into two lists. The elements up to and
+// including the one that it is located at stay in the list, and the rest are
+// moved to a new list which is returned. The iterator it stays valid.
+// This function is O(n), where n is the number of moved elements, since they
+// are counted to keep the list sizes right.
+func SplitAt[T any](it *FrwIterMut[T]) *List[T] {
+	it.mods.check(&it.lst.mods)
+	require(it.node.next != nil, "bad iterator")
+	res := New[T]()
+	n := 0
+	for node := it.node.next; node != it.lst.tail; node = node.next {
+		n++
+	}
+	if n == 0 {
+		return res
+	}
+	first, last := it.node.next, it.lst.tail.prev
+	it.lst.unlink(first, last, n)
+	res.linkAfter(res.head, first, last, n)
+	res.adopt(it.lst, first, last)
+	it.mods.sync(&it.lst.mods)
+	return res
+}
+
+// Merge merges two lists of any ordered type, both sorted in ascending order,
+// by moving all elements of l2 into l1. After the call, l1 is sorted and l2 is
+// empty. Merge is stable, equal elements of l1 come before the ones of l2.
+// This function is O(n + m), where n and m are lengths of l1 and l2.
+func Merge[T constraints.Ordered](l1, l2 *List[T]) {
+	MergeFunc(l1, l2, gcl.Less[T])
+}
+
+// MergeFunc is like Merge but the lists are sorted in ascending order as
+// determined by the `less` function.
+// This function is O(f * (n + m)), where n and m are lengths of l1 and l2 and
+// f is time complexity of the `less` function.
+func MergeFunc[T any](l1, l2 *List[T], less gcl.LessFn[T]) {
+	require(l1 != l2, "cannot merge a list with itself")
+	if l2.size == 0 {
+		return
+	}
+	l1.elems = l1.elems || l2.elems
+	l1.relink(mergeNodes(l1.chain(), l2.chain(), less))
+}
+
+// nodeAt returns the node at position i, where -1 <= i <= l.size. Positions
+// -1 and l.size refer to the head and tail sentinels. nodeAt walks from
+// whichever end is closer.
+func (l *List[T]) nodeAt(i int) *node[T] {
+	if i+1 <= l.size-i {
+		n := l.head
+		for j := -1; j < i; j++ {
+			n = n.next
+		}
+		return n
+	}
+	n := l.tail
+	for j := l.size; j > i; j-- {
+		n = n.prev
+	}
+	return n
 }
 
 func (l *List[T]) insertBetween(node, prev, next *node[T]) {
 	node.next = next
 	node.prev = prev
+	node.lst = l
 
 	next.prev = node
 	prev.next = node
 
 	l.size += 1
+	l.mods.inc()
 }
 
 func (l *List[T]) deleteNode(node *node[T]) (*node[T], *node[T]) {
@@ -285,13 +521,136 @@ func (l *List[T]) deleteNode(node *node[T]) (*node[T], *node[T]) {
 	prev.next = next
 	next.prev = prev
 
-	node = nil
+	node.lst = nil
 
 	l.size -= 1
+	l.mods.inc()
 
 	return prev, next
 }
 
+// unlink detaches the n nodes from first to last, inclusive, from the list.
+func (l *List[T]) unlink(first, last *node[T], n int) {
+	first.prev.next = last.next
+	last.next.prev = first.prev
+	l.size -= n
+	l.mods.inc()
+}
+
+// linkAfter links the n nodes from first to last, inclusive, next after node
+// prev of the list.
+func (l *List[T]) linkAfter(prev, first, last *node[T], n int) {
+	next := prev.next
+	prev.next = first
+	first.prev = prev
+	last.next = next
+	next.prev = last
+	l.size += n
+	l.mods.inc()
+}
+
+// adopt makes the nodes from first to last, inclusive, which were moved from
+// list src to l, belong to l. This is O(1) unless src has element handles.
+func (l *List[T]) adopt(src *List[T], first, last *node[T]) {
+	if src == l || !src.elems {
+		return
+	}
+	l.elems = true
+	for n := first; ; n = n.next {
+		n.lst = l
+		if n == last {
+			return
+		}
+	}
+}
+
+// chain detaches all nodes from the list and returns the first one. The
+// returned nodes form a nil-terminated chain through their next pointers.
+func (l *List[T]) chain() *node[T] {
+	if l.size == 0 {
+		return nil
+	}
+	first := l.head.next
+	l.tail.prev.next = nil
+	l.head.next = l.tail
+	l.tail.prev = l.head
+	l.size = 0
+	l.mods.inc()
+	return first
+}
+
+// relink appends a nil-terminated chain of nodes to the list and fixes their
+// prev pointers.
+func (l *List[T]) relink(first *node[T]) {
+	prev := l.tail.prev
+	for n := first; n != nil; n = n.next {
+		n.prev = prev
+		n.lst = l
+		prev.next = n
+		prev = n
+		l.size += 1
+	}
+	prev.next = l.tail
+	l.tail.prev = prev
+	l.mods.inc()
+}
+
+// sortNodes sorts the list using a bottom-up merge sort over its nodes. While
+// sorting, nodes are chained only through their next pointers. The prev
+// pointers are restored by relink at the end.
+func (l *List[T]) sortNodes(less gcl.LessFn[T]) {
+	if l.size < 2 {
+		return
+	}
+
+	// runs[i] is either nil or a sorted chain of 2^i nodes. Elements in
+	// runs[i+1] come before elements in runs[i] in the original order.
+	var runs [64]*node[T]
+	n := l.chain()
+	for n != nil {
+		next := n.next
+		n.next = nil
+		run := n
+		i := 0
+		for ; runs[i] != nil; i++ {
+			run = mergeNodes(runs[i], run, less)
+			runs[i] = nil
+		}
+		runs[i] = run
+		n = next
+	}
+	var sorted *node[T]
+	for _, run := range runs {
+		if run != nil {
+			sorted = mergeNodes(run, sorted, less)
+		}
+	}
+	l.relink(sorted)
+}
+
+// mergeNodes merges two sorted nil-terminated chains of nodes and returns the
+// first node of the merged chain. On ties, nodes of a come first.
+func mergeNodes[T any](a, b *node[T], less gcl.LessFn[T]) *node[T] {
+	var first node[T]
+	last := &first
+	for a != nil && b != nil {
+		if less(b.value, a.value) {
+			last.next = b
+			b = b.next
+		} else {
+			last.next = a
+			a = a.next
+		}
+		last = last.next
+	}
+	if a != nil {
+		last.next = a
+	} else {
+		last.next = b
+	}
+	return first.next
+}
+
 func toSlice[T any](l *List[T]) []T {
 	var res []T
 	for it := Iter(l); it.HasNext(); {
@@ -300,30 +659,20 @@ func toSlice[T any](l *List[T]) []T {
 	return res
 }
 
-// Sort sorts a list of any ordered type in ascending order.
+// Sort sorts a list of any ordered type in ascending order. The sort is
+// stable and it relinks the list nodes instead of moving values, so pointers
+// returned by mutable iterators keep referring to the same elements.
 // This function is O(n * log(n)), where n is length of the list.
 func Sort[T constraints.Ordered](l *List[T]) {
-	slice := toSlice(l)
-	slices.Sort(slice)
-	it := IterMut(l)
-	for _, v := range slice {
-		itVal := it.Next()
-		*itVal = v
-	}
+	l.sortNodes(gcl.Less[T])
 }
 
 // SortFunc sorts the given list in ascending order as determined by the `less`
-// function.
+// function. Like Sort, SortFunc is stable and keeps each value in its node.
 // This function is O(f * n * log(n)), where n is length of the list and
 // f is time complexity of the `less` function.
 func SortFunc[T any](l *List[T], less gcl.LessFn[T]) {
-	slice := toSlice(l)
-	slices.SortFunc(slice, less)
-	it := IterMut(l)
-	for _, v := range slice {
-		itVal := it.Next()
-		*itVal = v
-	}
+	l.sortNodes(less)
 }
 
 // IsSorted tests whether a list of any ordered type is sorted in ascending order.
@@ -364,72 +713,116 @@ func IsSortedFunc[T any](l *List[T], less gcl.LessFn[T]) bool {
 	return true
 }
 
+// RemoveIf deletes all elements of the list that satisfy the predicate `pred`
+// and returns the number of deleted elements.
+// This function is O(f * n), where n is length of the list and f is time
+// complexity of the `pred` function.
+func RemoveIf[T any](l *List[T], pred func(T) bool) int {
+	n := 0
+	for it := IterMut(l); it.HasNext(); {
+		if pred(*it.Next()) {
+			it.Delete()
+			n++
+		}
+	}
+	return n
+}
+
+// Remove deletes all elements of the list that are equal to v and returns the
+// number of deleted elements.
+// This function is O(n), where n is length of the list.
+func Remove[T comparable](l *List[T], v T) int {
+	return RemoveIf(l, func(e T) bool { return e == v })
+}
+
+// Retain keeps the elements of the list that satisfy the predicate `pred` and
+// deletes the rest.
+// This function is O(f * n), where n is length of the list and f is time
+// complexity of the `pred` function.
+func Retain[T any](l *List[T], pred func(T) bool) {
+	RemoveIf(l, func(v T) bool { return !pred(v) })
+}
+
+// MapInPlace replaces every element of the list with the result of applying
+// the function `fn` to it.
+// This function is O(f * n), where n is length of the list and f is time
+// complexity of the `fn` function.
+func MapInPlace[T any](l *List[T], fn func(T) T) {
+	for it := IterMut(l); it.HasNext(); {
+		v := it.Next()
+		*v = fn(*v)
+	}
+}
+
+// Partition moves the elements of the list that satisfy the predicate `pred`
+// to the first returned list and the rest to the second one, leaving l empty.
+// The relative order of elements is kept in both lists. The nodes are
+// relinked rather than copied, so pointers returned by mutable iterators keep
+// referring to the same elements.
+// This function is O(f * n), where n is length of the list and f is time
+// complexity of the `pred` function.
+func Partition[T any](l *List[T], pred func(T) bool) (*List[T], *List[T]) {
+	yes, no := New[T](), New[T]()
+	for n := l.chain(); n != nil; {
+		next := n.next
+		if pred(n.value) {
+			yes.linkAfter(yes.tail.prev, n, n, 1)
+			yes.adopt(l, n, n)
+		} else {
+			no.linkAfter(no.tail.prev, n, n, 1)
+			no.adopt(l, n, n)
+		}
+		n = next
+	}
+	return yes, no
+}
+
+// Unique deletes every element of the list that is equal to an element before
+// it, so only the first occurrence of each value is kept. Unlike Compact,
+// duplicates don't need to be adjacent.
+// This function is O(n), where n is length of the list.
+func Unique[T comparable](l *List[T]) {
+	seen := make(map[T]struct{}, l.size)
+	RemoveIf(l, func(v T) bool {
+		if _, ok := seen[v]; ok {
+			return true
+		}
+		seen[v] = struct{}{}
+		return false
+	})
+}
+
 // Compact replaces every consecutive group of equal elements with a single
-// copy. This is like the uniq Unix command.
+// copy. This is like the uniq Unix command. The first element of each group
+// is kept.
 // This function is O(n), where n is length of the list.
 func Compact[T comparable](l *List[T]) {
-	it1 := IterMut(l)
-	if !it1.HasNext() {
+	if l.size < 2 {
 		return
 	}
-	last := *it1.Next()
-	newSize := 1
-	if !it1.HasNext() {
-		return
-	}
-	v1 := it1.Next()
-
-	it2 := Iter(l)
-	it2.Next()
-	for it2.HasNext() {
-		v2 := it2.Next()
-		if v2 != last {
-			*v1 = v2
-			if it1.HasNext() {
-				v1 = it1.Next()
-			}
-			last = v2
-			newSize += 1
+	for node := l.head.next; node.next != l.tail; {
+		if node.next.value == node.value {
+			l.deleteNode(node.next)
+		} else {
+			node = node.next
 		}
 	}
-	it1.lst.tail.prev = it1.node.prev
-	it1.node.prev.next = it1.lst.tail
-
-	it1.lst.size = newSize
 }
 
 // CompactFunc is like Compact but it uses the `eq` function for comparison.
 // This function is O(f * n), where n is length of the list and f is time
 // complexity of the `eq` function.
 func CompactFunc[T any](l *List[T], eq gcl.EqualFn[T, T]) {
-	it1 := IterMut(l)
-	if !it1.HasNext() {
-		return
-	}
-	last := *it1.Next()
-	newSize := 1
-	if !it1.HasNext() {
+	if l.size < 2 {
 		return
 	}
-	v1 := it1.Next()
-
-	it2 := Iter(l)
-	it2.Next()
-	for it2.HasNext() {
-		v2 := it2.Next()
-		if !eq(v2, last) {
-			*v1 = v2
-			if it1.HasNext() {
-				v1 = it1.Next()
-			}
-			last = v2
-			newSize += 1
+	for node := l.head.next; node.next != l.tail; {
+		if eq(node.value, node.next.value) {
+			l.deleteNode(node.next)
+		} else {
+			node = node.next
 		}
 	}
-	it1.lst.tail.prev = it1.node.prev
-	it1.node.prev.next = it1.lst.tail
-
-	it1.lst.size = newSize
 }
 
 // Index returns the index of the first occurrence of v in l, or -1 if not
@@ -2,10 +2,14 @@ package lists
 
 import (
 	"encoding/json"
+	"math/rand"
 	"strings"
 	"testing"
 
 	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+	"golang.org/x/exp/slices"
 )
 
 var equalTests = []struct {
@@ -193,6 +197,187 @@ func TestSortFunc(t *testing.T) {
 	}
 }
 
+func TestSortStable(t *testing.T) {
+	type pair struct {
+		k, v int
+	}
+	less := func(a, b pair) bool { return a.k < b.k }
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 7, 64, 100, 1000} {
+		var s []pair
+		for i := 0; i < n; i++ {
+			s = append(s, pair{r.Intn(10), i})
+		}
+		l := New(s...)
+		SortFunc(l, less)
+		slices.SortStableFunc(s, less)
+		if got := toSlice(l); !slices.Equal(got, s) {
+			t.Errorf("SortFunc of %v elements got %v, want %v", n, got, s)
+		}
+		if !iters.Equal[pair](RIter(l), goslices.RIter(s)) {
+			t.Errorf("RIter does not match %v after SortFunc", s)
+		}
+	}
+}
+
+func TestSortKeepsNodes(t *testing.T) {
+	l := New(3, 1, 2)
+	it := IterMut(l)
+	p3, p1, p2 := it.Next(), it.Next(), it.Next()
+	Sort(l)
+	if *p1 != 1 || *p2 != 2 || *p3 != 3 {
+		t.Error("Sort must keep values in their nodes")
+	}
+	*p3 = 30
+	want := New(1, 2, 30)
+	if !Equal(l, want) {
+		t.Errorf("got %v, want %v", l, want)
+	}
+	if !iters.Equal[int](RIter(l), RIter(want)) {
+		t.Errorf("RIter(%v) does not match RIter(%v) after Sort", l, want)
+	}
+}
+
+func TestRemoveIf(t *testing.T) {
+	l := New(1, 2, 3, 4, 5, 6)
+	if n := RemoveIf(l, func(v int) bool { return v%3 != 0 }); n != 4 {
+		t.Errorf("RemoveIf(l, pred) = %v, want = 4", n)
+	}
+	if want := New(3, 6); !Equal(l, want) || Len(l) != 2 {
+		t.Errorf("got %v, want %v", l, want)
+	}
+	if n := RemoveIf(l, func(v int) bool { return true }); n != 2 || Len(l) != 0 {
+		t.Errorf("RemoveIf(l, true) = %v, want = 2", n)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	l := New(1, 2, 1, 3, 1)
+	if n := Remove(l, 1); n != 3 || !Equal(l, New(2, 3)) {
+		t.Errorf("Remove(l, 1) = %v, got %v, want 3, [2 3]", n, l)
+	}
+	if n := Remove(l, 4); n != 0 || Len(l) != 2 {
+		t.Errorf("Remove(l, 4) = %v, want = 0", n)
+	}
+}
+
+func TestRetain(t *testing.T) {
+	l := New(1, 2, 3, 4, 5)
+	Retain(l, func(v int) bool { return v%2 == 1 })
+	if want := New(1, 3, 5); !Equal(l, want) || Len(l) != 3 {
+		t.Errorf("got %v, want %v", l, want)
+	}
+}
+
+func TestMapInPlace(t *testing.T) {
+	l := New(1, 2, 3)
+	MapInPlace(l, func(v int) int { return v * 10 })
+	if want := New(10, 20, 30); !Equal(l, want) {
+		t.Errorf("got %v, want %v", l, want)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	l := New(1, 2, 3, 4, 5, 6, 7)
+	it := IterMut(l)
+	it.Next()
+	p2 := it.Next()
+	even, odd := Partition(l, func(v int) bool { return v%2 == 0 })
+	if !Equal(even, New(2, 4, 6)) || !Equal(odd, New(1, 3, 5, 7)) || Len(l) != 0 {
+		t.Errorf("Partition got %v, %v, %v", even, odd, l)
+	}
+	if Len(even) != 3 || Len(odd) != 4 || !iters.Equal[int](RIter(odd), RIter(New(1, 3, 5, 7))) {
+		t.Errorf("Partition got broken lists %v, %v", even, odd)
+	}
+	*p2 = 20
+	if Front(even) != 20 {
+		t.Error("Partition must keep values in their nodes")
+	}
+}
+
+func TestUnique(t *testing.T) {
+	l := New(3, 1, 3, 2, 1, 3, 4)
+	Unique(l)
+	if want := New(3, 1, 2, 4); !Equal(l, want) || Len(l) != 4 {
+		t.Errorf("got %v, want %v", l, want)
+	}
+}
+
+func TestAtSet(t *testing.T) {
+	l := New(0, 1, 2, 3, 4, 5)
+	for i := 0; i < Len(l); i++ {
+		if At(l, i) != i {
+			t.Errorf("At(l, %v) = %v, want = %v", i, At(l, i), i)
+		}
+	}
+	Set(l, 1, 10)
+	Set(l, 4, 40)
+	if want := New(0, 10, 2, 3, 40, 5); !Equal(l, want) {
+		t.Errorf("got %v, want %v", l, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("At out of range must panic")
+		}
+	}()
+	At(l, 6)
+}
+
+func TestInsertDeleteAt(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	l := New[int]()
+	var s []int
+	for i := 0; i < 500; i++ {
+		if len(s) > 0 && r.Intn(3) == 0 {
+			j := r.Intn(len(s))
+			DeleteAt(l, j)
+			s = append(s[:j], s[j+1:]...)
+		} else {
+			j := r.Intn(len(s) + 1)
+			InsertAt(l, j, i, -i)
+			s = append(s[:j], append([]int{i, -i}, s[j:]...)...)
+		}
+	}
+	if got := toSlice(l); !slices.Equal(got, s) || Len(l) != len(s) {
+		t.Errorf("got %v, want %v", got, s)
+	}
+	if !iters.Equal[int](RIter(l), goslices.RIter(s)) {
+		t.Error("RIter does not match after InsertAt and DeleteAt")
+	}
+}
+
+func TestIterAt(t *testing.T) {
+	l := New(1, 2, 3, 4, 5)
+	it := IterAt(l, 3)
+	if it.Remaining() != 1 || *it.Next() != 5 {
+		t.Error("IterAt(l, 3) must be located at the fourth element")
+	}
+	it = IterAt(l, 1)
+	it.Delete()
+	if want := New(1, 3, 4, 5); !Equal(l, want) || *it.Next() != 3 {
+		t.Errorf("got %v, want %v", l, want)
+	}
+	if it = IterAt(l, -1); *it.Next() != 1 {
+		t.Error("IterAt(l, -1) must be located at one-before-first")
+	}
+}
+
+func TestSlice(t *testing.T) {
+	l := New(0, 1, 2, 3, 4, 5, 6)
+	if got := goslices.FromIter[int](Slice(l, 2, 5)); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("Slice(l, 2, 5) yielded %v, want [2 3 4]", got)
+	}
+	if got := goslices.FromIter[int](Slice(l, 5, 7)); !slices.Equal(got, []int{5, 6}) {
+		t.Errorf("Slice(l, 5, 7) yielded %v, want [5 6]", got)
+	}
+	if it := Slice(l, 3, 3); it.HasNext() || iters.Len[int](it) != 0 {
+		t.Error("an empty range must yield nothing")
+	}
+	if n := iters.Sum[int](Slice(l, 0, 7)); n != 21 {
+		t.Errorf("Sum(Slice(l, 0, 7)) = %v, want = 21", n)
+	}
+}
+
 var reverseTests = []struct {
 	l    *List[int]
 	want *List[int]
@@ -244,12 +429,16 @@ var compactTests = []struct {
 		New(1, 2, 2, 3, 3, 4),
 		New(1, 2, 3, 4),
 	},
+	{
+		New(1, 1, 2),
+		New(1, 2),
+	},
 }
 
 func TestCompact(t *testing.T) {
 	for _, test := range compactTests {
 		cloned := Clone(test.l)
-		if Compact(cloned); !Equal(cloned, test.want) {
+		if Compact(cloned); !Equal(cloned, test.want) || Len(cloned) != Len(test.want) || !iters.Equal[int](RIter(cloned), RIter(test.want)) {
 			t.Errorf("Compact(%v) got %v, want %v", test.l, cloned, test.want)
 		}
 	}
@@ -258,7 +447,7 @@ func TestCompact(t *testing.T) {
 func TestCompactFunc(t *testing.T) {
 	for _, test := range compactTests {
 		cloned := Clone(test.l)
-		if CompactFunc(cloned, gcl.Equal[int]); !Equal(cloned, test.want) {
+		if CompactFunc(cloned, gcl.Equal[int]); !Equal(cloned, test.want) || Len(cloned) != Len(test.want) || !iters.Equal[int](RIter(cloned), RIter(test.want)) {
 			t.Errorf("CompactFunc(%v, gcl.Equal[int]) got %v, want %v", test.l, cloned, test.want)
 		}
 	}
@@ -379,3 +568,125 @@ func TestJSONSerialization(t *testing.T) {
 		t.Error("Wrong JSON serialization")
 	}
 }
+
+var spliceTests = []struct {
+	dst      []int
+	src      []int
+	dstPos   uint
+	from, to uint
+	wantDst  []int
+	wantSrc  []int
+}{
+	{[]int{1, 5}, []int{2, 3, 4}, 1, 0, 3, []int{1, 2, 3, 4, 5}, nil},
+	{[]int{1, 5}, []int{9, 2, 3, 9}, 1, 1, 3, []int{1, 2, 3, 5}, []int{9, 9}},
+	{nil, []int{1, 2}, 0, 1, 1, nil, []int{1, 2}},
+	{[]int{1}, []int{2, 3}, 0, 0, 1, []int{2, 1}, []int{3}},
+}
+
+func TestSplice(t *testing.T) {
+	for _, test := range spliceTests {
+		dst, src := New(test.dst...), New(test.src...)
+		dstIt, from, to := IterMut(dst), IterMut(src), IterMut(src)
+		iters.Advance[*int](dstIt, test.dstPos)
+		iters.Advance[*int](from, test.from)
+		iters.Advance[*int](to, test.to)
+		Splice(dstIt, src, from, to)
+		if !Equal(dst, New(test.wantDst...)) || !Equal(src, New(test.wantSrc...)) {
+			t.Errorf("Splice got %v, %v, want %v, %v", dst, src, test.wantDst, test.wantSrc)
+		}
+		if Len(dst) != len(test.wantDst) || Len(src) != len(test.wantSrc) {
+			t.Errorf("Len(dst), Len(src) = %v, %v, want = %v, %v", Len(dst), Len(src), len(test.wantDst), len(test.wantSrc))
+		}
+		if test.from != test.to && to.Remaining() != dstIt.Remaining()-int(test.to-test.from) {
+			t.Errorf("to.Remaining() = %v after Splice, want %v", to.Remaining(), dstIt.Remaining()-int(test.to-test.from))
+		}
+	}
+}
+
+func TestSpliceSameList(t *testing.T) {
+	l := New(1, 2, 3, 4, 5)
+	dst, from, to := IterMut(l), IterMut(l), IterMut(l)
+	iters.Advance[*int](dst, 5)
+	iters.Advance[*int](from, 1)
+	iters.Advance[*int](to, 3)
+	Splice(dst, l, from, to)
+	if want := New(1, 4, 5, 2, 3); !Equal(l, want) || Len(l) != 5 {
+		t.Errorf("Splice got %v, want %v", l, want)
+	}
+	if dst.index != 2 || to.index != 4 || to.HasNext() {
+		t.Errorf("dst.index, to.index = %v, %v, want = 2, 4", dst.index, to.index)
+	}
+	if !iters.Equal[int](RIter(l), RIter(New(1, 4, 5, 2, 3))) {
+		t.Errorf("RIter(%v) is broken after Splice", l)
+	}
+
+	// dst before from moves the range, and so from, to the right.
+	l = New(1, 2, 3, 4, 5)
+	dst, from, to = IterMut(l), IterMut(l), IterMut(l)
+	iters.Advance[*int](dst, 1)
+	iters.Advance[*int](from, 3)
+	iters.Advance[*int](to, 5)
+	Splice(dst, l, from, to)
+	if want := New(1, 4, 5, 2, 3); !Equal(l, want) {
+		t.Errorf("Splice got %v, want %v", l, want)
+	}
+	if from.Remaining() != 0 || to.Remaining() != 2 {
+		t.Errorf("from.Remaining(), to.Remaining() = %v, %v, want = 0, 2", from.Remaining(), to.Remaining())
+	}
+	if rest := SplitAt(from); rest.String() != "lists.List[]" || !Equal(l, New(1, 4, 5, 2, 3)) || Len(l) != 5 {
+		t.Errorf("SplitAt after Splice got %v, %v, want %v, []", l, rest, New(1, 4, 5, 2, 3))
+	}
+}
+
+func TestConcat(t *testing.T) {
+	l1, l2 := New(1, 2), New(3, 4)
+	it := IterMut(l2)
+	p := it.Next()
+	Concat(l1, l2)
+	*p = 30
+	if want := New(1, 2, 30, 4); !Equal(l1, want) || Len(l2) != 0 {
+		t.Errorf("Concat got %v, %v, want %v, []", l1, l2, want)
+	}
+	Concat(l2, l1)
+	if want := New(1, 2, 30, 4); !Equal(l2, want) || Len(l1) != 0 || !iters.Equal[int](RIter(l2), RIter(want)) {
+		t.Errorf("Concat got %v, %v, want [], %v", l1, l2, want)
+	}
+}
+
+func TestSplitAt(t *testing.T) {
+	l := New(1, 2, 3, 4)
+	it := IterMut(l)
+	iters.Advance[*int](it, 1)
+	rest := SplitAt(it)
+	if !Equal(l, New(1)) || !Equal(rest, New(2, 3, 4)) || Len(rest) != 3 || Len(l) != 1 {
+		t.Errorf("SplitAt got %v, %v, want [1], [2 3 4]", l, rest)
+	}
+	if it.HasNext() {
+		t.Error("iterator must be at the end of the list after SplitAt")
+	}
+	if rest = SplitAt(IterMut(l)); !Equal(rest, New(1)) || Len(l) != 0 {
+		t.Errorf("SplitAt at the beginning got %v, %v, want [], [1]", l, rest)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	l1, l2 := New(1, 3, 5, 7), New(2, 3, 4, 8, 9)
+	Merge(l1, l2)
+	want := New(1, 2, 3, 3, 4, 5, 7, 8, 9)
+	if !Equal(l1, want) || Len(l2) != 0 || Len(l1) != 9 {
+		t.Errorf("Merge got %v, %v, want %v, []", l1, l2, want)
+	}
+	if !iters.Equal[int](RIter(l1), RIter(want)) {
+		t.Errorf("RIter(%v) is broken after Merge", l1)
+	}
+
+	type pair struct {
+		k, v int
+	}
+	less := func(a, b pair) bool { return a.k < b.k }
+	p1, p2 := New(pair{1, 1}, pair{2, 1}), New(pair{1, 2}, pair{2, 2})
+	MergeFunc(p1, p2, less)
+	if got, want := toSlice(p1), []pair{{1, 1}, {1, 2}, {2, 1}, {2, 2}}; !slices.Equal(got, want) {
+		t.Errorf("MergeFunc got %v, want %v", got, want)
+	}
+}
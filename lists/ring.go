@@ -0,0 +1,154 @@
+package lists
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Ring is a circular doubly linked list. A ring has no beginning or end.
+// Instead, it has a current element that can be rotated around the ring.
+// The zero value of Ring is an empty ring ready to use.
+type Ring[T any] struct {
+	cur  *node[T]
+	size int
+}
+
+// NewRing creates a new ring containing the given elements, in order, and
+// returns a pointer to it. The first element is the current one.
+// This function is O(len(elems)).
+func NewRing[T any](elems ...T) *Ring[T] {
+	r := &Ring[T]{}
+	r.InsertAfter(elems...)
+	return r
+}
+
+func (r *Ring[T]) String() string {
+	var b strings.Builder
+	b.WriteString("lists.Ring[")
+	it := r.Iter(0)
+	for it.HasNext() {
+		fmt.Fprintf(&b, "%v", it.Next())
+		if it.HasNext() {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Len returns the number of elements in the ring. This function is O(1).
+func (r *Ring[T]) Len() int {
+	return r.size
+}
+
+// Current returns the current element of the ring. It panics if the ring is
+// empty.
+// This function is O(1).
+func (r *Ring[T]) Current() T {
+	require(r.size > 0, "ring cannot be empty")
+	return r.cur.value
+}
+
+// Rotate moves the current element n steps forward around the ring, or
+// backward if n is negative. Rotating an empty ring does nothing.
+// This function is O(min(k, Len() - k)), where k is n modulo Len().
+func (r *Ring[T]) Rotate(n int) {
+	r.cur = r.move(n)
+}
+
+// InsertAfter inserts the given elements right after the current element, in
+// order. The current element doesn't change, unless the ring is empty. In
+// that case, the first inserted element becomes the current one.
+// This function is O(len(elems)).
+func (r *Ring[T]) InsertAfter(elems ...T) {
+	if len(elems) == 0 {
+		return
+	}
+	prev := r.cur
+	if prev == nil {
+		prev = &node[T]{value: elems[0]}
+		prev.next, prev.prev = prev, prev
+		r.cur = prev
+		r.size = 1
+		elems = elems[1:]
+	}
+	for _, elem := range elems {
+		n := &node[T]{value: elem, prev: prev, next: prev.next}
+		prev.next.prev = n
+		prev.next = n
+		prev = n
+		r.size += 1
+	}
+}
+
+// RemoveCurrent deletes the current element of the ring and returns it. The
+// element after it becomes the current one. It panics if the ring is empty.
+// This function is O(1).
+func (r *Ring[T]) RemoveCurrent() T {
+	require(r.size > 0, "ring cannot be empty")
+	n := r.cur
+	r.size -= 1
+	if r.size == 0 {
+		r.cur = nil
+		return n.value
+	}
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	r.cur = n.next
+	return n.value
+}
+
+// Iter returns an iterator that goes around the ring exactly once. The
+// iterator starts at the element `start` steps away from the current one,
+// counting forward, or backward if start is negative.
+func (r *Ring[T]) Iter(start int) *RingIter[T] {
+	return &RingIter[T]{
+		node:      r.move(start),
+		remaining: r.size,
+	}
+}
+
+// move returns the node n steps away from the current one.
+func (r *Ring[T]) move(n int) *node[T] {
+	if r.size == 0 {
+		return nil
+	}
+	n %= r.size
+	if n < 0 {
+		n += r.size
+	}
+	cur := r.cur
+	if n <= r.size/2 {
+		for ; n > 0; n-- {
+			cur = cur.next
+		}
+	} else {
+		for ; n < r.size; n++ {
+			cur = cur.prev
+		}
+	}
+	return cur
+}
+
+// RingIter is an iterator that goes around a ring once.
+type RingIter[T any] struct {
+	node      *node[T]
+	remaining int
+}
+
+func (it *RingIter[T]) HasNext() bool {
+	return it.remaining > 0
+}
+
+func (it *RingIter[T]) Next() T {
+	require(it.HasNext(), "iterator must have next")
+
+	v := it.node.value
+	it.node = it.node.next
+	it.remaining -= 1
+	return v
+}
+
+func (it *RingIter[T]) Remaining() int {
+	return it.remaining
+}
@@ -0,0 +1,70 @@
+package lists
+
+import (
+	"testing"
+
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+)
+
+func TestRingRotate(t *testing.T) {
+	r := NewRing(0, 1, 2, 3, 4)
+	for _, test := range []struct {
+		n, want int
+	}{
+		{0, 0}, {1, 1}, {3, 4}, {-2, 2}, {7, 4}, {-13, 1},
+	} {
+		r.Rotate(test.n)
+		if r.Current() != test.want {
+			t.Errorf("Rotate(%v) made current %v, want %v", test.n, r.Current(), test.want)
+		}
+	}
+
+	var empty Ring[int]
+	empty.Rotate(3)
+	if empty.Len() != 0 {
+		t.Errorf("empty.Len() = %v, want = 0", empty.Len())
+	}
+}
+
+func TestRingInsertRemove(t *testing.T) {
+	var r Ring[int]
+	r.InsertAfter(1, 4)
+	r.InsertAfter(2, 3)
+	if r.Len() != 4 || r.Current() != 1 {
+		t.Errorf("r.Len(), r.Current() = %v, %v, want = 4, 1", r.Len(), r.Current())
+	}
+	if !iters.Equal[int](r.Iter(0), goslices.Iter([]int{1, 2, 3, 4})) {
+		t.Errorf("got %v, want [1 2 3 4]", &r)
+	}
+	if v := r.RemoveCurrent(); v != 1 || r.Current() != 2 || r.Len() != 3 {
+		t.Errorf("r.RemoveCurrent() = %v, got %v", v, &r)
+	}
+	for r.Len() > 0 {
+		r.RemoveCurrent()
+	}
+	r.InsertAfter(5)
+	if r.Len() != 1 || r.Current() != 5 {
+		t.Errorf("got %v, want [5]", &r)
+	}
+}
+
+func TestRingIter(t *testing.T) {
+	r := NewRing(1, 2, 3, 4)
+	if !iters.Equal[int](r.Iter(2), goslices.Iter([]int{3, 4, 1, 2})) {
+		t.Error("Iter(2) must start at the third element and make one lap")
+	}
+	if !iters.Equal[int](r.Iter(-1), goslices.Iter([]int{4, 1, 2, 3})) {
+		t.Error("Iter(-1) must start at the last element and make one lap")
+	}
+	if it := r.Iter(0); iters.Len[int](it) != 4 {
+		t.Errorf("Len(r.Iter(0)) = %v, want = 4", iters.Len[int](it))
+	}
+	var empty Ring[int]
+	if empty.Iter(1).HasNext() {
+		t.Error("iterator of an empty ring must be empty")
+	}
+	if s := r.String(); s != "lists.Ring[1 2 3 4]" {
+		t.Errorf("r.String() = %v, want = lists.Ring[1 2 3 4]", s)
+	}
+}
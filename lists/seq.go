@@ -0,0 +1,53 @@
+//go:build go1.23
+
+package lists
+
+import "iter"
+
+// All returns an iter.Seq2 over index-value pairs of the given list in the
+// usual order, so that the list can be used in a range loop:
+//
+//	for i, v := range lists.All(l) {
+//		...
+//	}
+//
+// The list must not be modified during the iteration.
+func All[T any](l *List[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for n := l.head.next; n != l.tail; n = n.next {
+			if !yield(i, n.value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Values returns an iter.Seq over the values of the given list in the usual
+// order.
+// The list must not be modified during the iteration.
+func Values[T any](l *List[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.head.next; n != l.tail; n = n.next {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iter.Seq2 over index-value pairs of the given list,
+// traversing it backward with descending indices.
+// The list must not be modified during the iteration.
+func Backward[T any](l *List[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := l.size - 1
+		for n := l.tail.prev; n != l.head; n = n.prev {
+			if !yield(i, n.value) {
+				return
+			}
+			i--
+		}
+	}
+}
@@ -0,0 +1,36 @@
+//go:build go1.23
+
+package lists
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	l := New(10, 20, 30)
+	var idx, vals []int
+	for i, v := range All(l) {
+		idx = append(idx, i)
+		vals = append(vals, v)
+	}
+	if !slices.Equal(idx, []int{0, 1, 2}) || !slices.Equal(vals, []int{10, 20, 30}) {
+		t.Errorf("All(%v) yielded %v, %v", l, idx, vals)
+	}
+
+	idx, vals = nil, nil
+	for i, v := range Backward(l) {
+		idx = append(idx, i)
+		vals = append(vals, v)
+		if i == 1 {
+			break
+		}
+	}
+	if !slices.Equal(idx, []int{2, 1}) || !slices.Equal(vals, []int{30, 20}) {
+		t.Errorf("Backward(%v) yielded %v, %v", l, idx, vals)
+	}
+
+	if got := slices.Collect(Values(l)); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Errorf("Values(%v) yielded %v", l, got)
+	}
+}
@@ -0,0 +1,37 @@
+//go:build !gcldebug
+
+package lists
+
+import "testing"
+
+// Without gcldebug, iterators may be used after their list was modified.
+// Their positions are stale then, but functions relinking nodes must still
+// keep the list sizes right.
+
+func TestSplitAtStaleIterator(t *testing.T) {
+	l := New(1, 2, 3)
+	it := IterMut(l)
+	it.Next()
+	PushFront(l, 0)
+	rest := SplitAt(it)
+	if !Equal(l, New(0, 1)) || Len(l) != 2 || !Equal(rest, New(2, 3)) || Len(rest) != 2 {
+		t.Errorf("SplitAt got %v, %v with lengths %v, %v, want [0 1], [2 3]", l, rest, Len(l), Len(rest))
+	}
+	PopFront(rest)
+	PopFront(rest)
+	if Len(rest) != 0 {
+		t.Errorf("Len(rest) = %v, want = 0", Len(rest))
+	}
+}
+
+func TestSpliceStaleIterator(t *testing.T) {
+	src, dst := New(1, 2, 3), New(4)
+	from, to := IterMut(src), IterMut(src)
+	to.Next()
+	to.Next()
+	PushFront(src, 0)
+	Splice(IterMut(dst), src, from, to)
+	if !Equal(src, New(3)) || Len(src) != 1 || !Equal(dst, New(0, 1, 2, 4)) || Len(dst) != 4 {
+		t.Errorf("Splice got %v, %v with lengths %v, %v, want [3], [0 1 2 4]", src, dst, Len(src), Len(dst))
+	}
+}
@@ -0,0 +1,29 @@
+package plists
+
+func require(check bool, failMsg string) {
+	if !check {
+		panic(failMsg)
+	}
+}
+
+// FrwIter is a persistent list forward iterator. FrwIter implements
+// iters.SizedIterator[T].
+type FrwIter[T any] struct {
+	lst *List[T]
+}
+
+func (it *FrwIter[T]) HasNext() bool {
+	return it.lst != nil
+}
+
+func (it *FrwIter[T]) Next() T {
+	require(it.HasNext(), "iterator must have next")
+
+	v := it.lst.head
+	it.lst = it.lst.tail
+	return v
+}
+
+func (it *FrwIter[T]) Remaining() int {
+	return Len(it.lst)
+}
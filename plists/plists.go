@@ -0,0 +1,171 @@
+// Package plists provides a persistent singly linked list and various
+// functions useful with persistent lists of any type.
+//
+// A persistent list is immutable. Operations that "modify" a list return a new
+// list and leave the given one unchanged. New lists share as many nodes as
+// possible with the lists they are built from. Because nothing is ever
+// mutated, lists can be shared between goroutines without locks.
+package plists
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/iters"
+	"github.com/shayanh/gcl/lists"
+)
+
+// List is a persistent singly linked list. A nil *List is the empty list, so
+// the zero value is ready to use. Each non-empty list is a head element and a
+// tail list.
+type List[T any] struct {
+	head T
+	tail *List[T]
+	size int
+}
+
+// New creates a new list containing the given elements.
+// This function is O(len(elems)).
+func New[T any](elems ...T) *List[T] {
+	var l *List[T]
+	for i := len(elems) - 1; i >= 0; i-- {
+		l = Cons(elems[i], l)
+	}
+	return l
+}
+
+// FromIter builds a new list from the given iterator.
+func FromIter[T any](it iters.Iterator[T]) *List[T] {
+	var elems []T
+	for it.HasNext() {
+		elems = append(elems, it.Next())
+	}
+	return New(elems...)
+}
+
+// FromList builds a new persistent list with the elements of the given
+// mutable list.
+// This function is O(n), where n is length of the list.
+func FromList[T any](l *lists.List[T]) *List[T] {
+	var res *List[T]
+	for it := lists.RIter(l); it.HasNext(); {
+		res = Cons(it.Next(), res)
+	}
+	return res
+}
+
+// ToList builds a new mutable list with the elements of the given persistent
+// list.
+// This function is O(n), where n is length of the list.
+func ToList[T any](l *List[T]) *lists.List[T] {
+	return lists.FromIter[T](Iter(l))
+}
+
+func (l *List[T]) String() string {
+	var b strings.Builder
+	b.WriteString("plists.List[")
+	it := Iter(l)
+	for it.HasNext() {
+		v := it.Next()
+		fmt.Fprintf(&b, "%v", v)
+		if it.HasNext() {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Len returns size of the given list. This function is O(1).
+func Len[T any](l *List[T]) int {
+	if l == nil {
+		return 0
+	}
+	return l.size
+}
+
+// Cons returns a new list with v as its head and l as its tail. The returned
+// list shares all nodes of l.
+// This function is O(1).
+func Cons[T any](v T, l *List[T]) *List[T] {
+	return &List[T]{
+		head: v,
+		tail: l,
+		size: Len(l) + 1,
+	}
+}
+
+// Head returns the first element of the list. It panics if the given list is
+// empty.
+// This function is O(1).
+func Head[T any](l *List[T]) T {
+	require(l != nil, "list cannot be empty")
+	return l.head
+}
+
+// Tail returns the list of all elements except the first one. It panics if
+// the given list is empty.
+// This function is O(1).
+func Tail[T any](l *List[T]) *List[T] {
+	require(l != nil, "list cannot be empty")
+	return l.tail
+}
+
+// Iter returns an forward iterator to the beginning.
+func Iter[T any](l *List[T]) *FrwIter[T] {
+	return &FrwIter[T]{
+		lst: l,
+	}
+}
+
+// Equal tests whether two lists are equal: the same length and all elements
+// equal. Floating point NaNs are not considered equal.
+// This function is O(min(Len(l1), Len(l2))).
+func Equal[T comparable](l1, l2 *List[T]) bool {
+	return EqualFunc(l1, l2, gcl.Equal[T])
+}
+
+// EqualFunc tests whether two lists are equal using the given `eq` function.
+// This function is O(f * min(Len(l1), Len(l2))), where f is the time
+// complexity of the `eq` function.
+func EqualFunc[T1 any, T2 any](l1 *List[T1], l2 *List[T2], eq gcl.EqualFn[T1, T2]) bool {
+	if Len(l1) != Len(l2) {
+		return false
+	}
+	for ; l1 != nil; l1, l2 = l1.tail, l2.tail {
+		if !eq(l1.head, l2.head) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reverse returns a new list with the elements of l in reverse order.
+// This function is O(n), where n is length of the list.
+func Reverse[T any](l *List[T]) *List[T] {
+	var res *List[T]
+	for ; l != nil; l = l.tail {
+		res = Cons(l.head, res)
+	}
+	return res
+}
+
+// Append returns a new list with the elements of l1 followed by the elements
+// of l2. The elements of l1 are copied and the returned list shares all nodes
+// of l2.
+// This function is O(Len(l1)).
+func Append[T any](l1, l2 *List[T]) *List[T] {
+	if l1 == nil {
+		return l2
+	}
+	elems := make([]T, 0, l1.size)
+	for ; l1 != nil; l1 = l1.tail {
+		elems = append(elems, l1.head)
+	}
+	res := l2
+	for i := len(elems) - 1; i >= 0; i-- {
+		res = Cons(elems[i], res)
+	}
+	return res
+}
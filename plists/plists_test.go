@@ -0,0 +1,115 @@
+package plists
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+	"github.com/shayanh/gcl/lists"
+	"golang.org/x/exp/slices"
+)
+
+func TestConsHeadTail(t *testing.T) {
+	var empty *List[int]
+	l1 := Cons(1, empty)
+	l2 := Cons(2, l1)
+	l3 := Cons(3, l1)
+	if Len(empty) != 0 || Len(l1) != 1 || Len(l2) != 2 {
+		t.Errorf("Len = %v, %v, %v, want = 0, 1, 2", Len(empty), Len(l1), Len(l2))
+	}
+	if Head(l2) != 2 || Head(l3) != 3 || Tail(l2) != l1 || Tail(l3) != l1 {
+		t.Error("lists built with Cons must share their tails")
+	}
+	if !Equal(l1, New(1)) || !Equal(l2, New(2, 1)) {
+		t.Errorf("got %v, %v, want [1], [2 1]", l1, l2)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Head of an empty list must panic")
+		}
+	}()
+	Head(empty)
+}
+
+func TestIter(t *testing.T) {
+	s := []int{1, 2, 3}
+	l := New(s...)
+	if !iters.Equal[int](Iter(l), goslices.Iter(s)) {
+		t.Errorf("Iter(%v) does not match %v", l, s)
+	}
+	if n := iters.Len[int](Iter(l)); n != 3 {
+		t.Errorf("Len(Iter(l)) = %v, want = 3", n)
+	}
+	if Iter[int](nil).HasNext() {
+		t.Error("iterator of an empty list must be empty")
+	}
+	if got := FromIter[int](goslices.Iter(s)); !Equal(got, l) {
+		t.Errorf("FromIter(%v) = %v", s, got)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	l := New(1, 2, 3)
+	if r := Reverse(l); !Equal(r, New(3, 2, 1)) || !Equal(l, New(1, 2, 3)) {
+		t.Errorf("Reverse(%v) = %v", l, r)
+	}
+	if r := Reverse[int](nil); r != nil {
+		t.Errorf("Reverse(nil) = %v, want = nil", r)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	l1, l2 := New(1, 2), New(3, 4)
+	l := Append(l1, l2)
+	if !Equal(l, New(1, 2, 3, 4)) || Len(l) != 4 {
+		t.Errorf("Append(%v, %v) = %v", l1, l2, l)
+	}
+	if Tail(Tail(l)) != l2 {
+		t.Error("Append must share the nodes of the second list")
+	}
+	if !Equal(l1, New(1, 2)) {
+		t.Error("Append must not modify the first list")
+	}
+	if Append(nil, l2) != l2 || !Equal(Append(l1, nil), l1) {
+		t.Error("wrong Append behavior with empty lists")
+	}
+}
+
+func TestLists(t *testing.T) {
+	ml := lists.New(1, 2, 3)
+	l := FromList(ml)
+	lists.PushBack(ml, 4)
+	if !Equal(l, New(1, 2, 3)) {
+		t.Errorf("FromList got %v, want [1 2 3]", l)
+	}
+	if got := ToList(l); !lists.Equal(got, lists.New(1, 2, 3)) {
+		t.Errorf("ToList(%v) = %v", l, got)
+	}
+}
+
+func TestConcurrentSharing(t *testing.T) {
+	base := New(1, 2, 3)
+	var wg sync.WaitGroup
+	results := make([][]int, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l := base
+			for j := 0; j < 100; j++ {
+				l = Cons(i, l)
+			}
+			results[i] = goslices.FromIter[int](Iter(Reverse(l)))[:3]
+		}(i)
+	}
+	wg.Wait()
+	for _, res := range results {
+		if !slices.Equal(res, []int{3, 2, 1}) {
+			t.Errorf("got %v, want [3 2 1]", res)
+		}
+	}
+	if !Equal(base, New(1, 2, 3)) {
+		t.Errorf("shared list changed to %v", base)
+	}
+}
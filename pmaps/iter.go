@@ -0,0 +1,23 @@
+package pmaps
+
+import (
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/internal/hamt"
+)
+
+// Iterator is an iterator over the elements of a persistent map.
+type Iterator[K any, V any] struct {
+	it *hamt.Iterator[K, V]
+}
+
+func (it *Iterator[K, V]) HasNext() bool {
+	return it.it.HasNext()
+}
+
+func (it *Iterator[K, V]) Next() gcl.MapElem[K, V] {
+	e := it.it.Next()
+	return gcl.MapElem[K, V]{
+		Key:   e.Key,
+		Value: e.Value,
+	}
+}
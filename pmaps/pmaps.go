@@ -0,0 +1,175 @@
+// Package pmaps provides a persistent hash map and various functions useful
+// with persistent maps of any type.
+//
+// A persistent map is immutable. Put and Delete return a new version of the
+// map and leave the given one unchanged. Versions share the parts of their
+// structure that did not change, so a new version costs O(log32(n)) time and
+// memory. Because nothing is ever mutated, maps can be shared between
+// goroutines without locks. For bulk updates, use a Builder.
+package pmaps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/internal/hamt"
+	"github.com/shayanh/gcl/iters"
+)
+
+// Map is a persistent hash map implemented as a hash array mapped trie
+// (HAMT).
+type Map[K any, V any] struct {
+	trie hamt.Trie[K, V]
+}
+
+// New creates a new empty map that uses the given hash and equal functions
+// for keys and returns a pointer to it. Keys that are equal according to `eq`
+// must have the same hash.
+func New[K any, V any](hash gcl.HashFn[K], eq gcl.EqualFn[K, K]) *Map[K, V] {
+	return &Map[K, V]{
+		trie: hamt.New[K, V](hash, eq),
+	}
+}
+
+// FromIter builds a new map from the given iterator, using the given hash and
+// equal functions for keys. For duplicate keys, the last value wins. FromIter
+// uses a Builder, so it does not create intermediate versions.
+func FromIter[K any, V any](it iters.Iterator[gcl.MapElem[K, V]], hash gcl.HashFn[K], eq gcl.EqualFn[K, K]) *Map[K, V] {
+	b := NewBuilder(New[K, V](hash, eq))
+	for it.HasNext() {
+		elem := it.Next()
+		b.Put(elem.Key, elem.Value)
+	}
+	return b.Map()
+}
+
+func (m *Map[K, V]) String() string {
+	var b strings.Builder
+	b.WriteString("pmaps.Map[")
+	it := Iter(m)
+	for it.HasNext() {
+		elem := it.Next()
+		fmt.Fprintf(&b, "%v:%v", elem.Key, elem.Value)
+		if it.HasNext() {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Len returns the number of elements in the given map. This function is O(1).
+func Len[K any, V any](m *Map[K, V]) int {
+	return m.trie.Len()
+}
+
+// Get returns the value associated with key k. The boolean value is false if
+// the key is not in the map.
+// This function is O(log32(n)), where n is size of the map.
+func Get[K any, V any](m *Map[K, V], k K) (v V, ok bool) {
+	if e := m.trie.Get(k); e != nil {
+		return e.Value, true
+	}
+	return
+}
+
+// Contains tests whether key k is in the map.
+// This function is O(log32(n)), where n is size of the map.
+func Contains[K any, V any](m *Map[K, V], k K) bool {
+	return m.trie.Get(k) != nil
+}
+
+// Put returns a new version of the map in which key k is associated with
+// value v. The given map is not changed.
+// This function is O(log32(n)), where n is size of the map.
+func Put[K any, V any](m *Map[K, V], k K, v V) *Map[K, V] {
+	return &Map[K, V]{
+		trie: m.trie.Put(k, v),
+	}
+}
+
+// Delete returns a new version of the map without key k. If k is not in the
+// map, m itself is returned.
+// This function is O(log32(n)), where n is size of the map.
+func Delete[K any, V any](m *Map[K, V], k K) *Map[K, V] {
+	trie := m.trie.Delete(k)
+	if trie.Len() == m.trie.Len() {
+		return m
+	}
+	return &Map[K, V]{
+		trie: trie,
+	}
+}
+
+// Iter returns an iterator over the elements of the given map. The iteration
+// order is determined by the hashes of the keys.
+func Iter[K any, V any](m *Map[K, V]) *Iterator[K, V] {
+	return &Iterator[K, V]{
+		it: m.trie.Iter(),
+	}
+}
+
+// Diff returns an iterator over the keys that are added, deleted or updated
+// in map cur compared to map old. A key counts as updated if it was put
+// again, even with an equal value. Both maps must use the same hash and equal
+// functions. The parts that old and cur share are skipped, so for two
+// versions of a map, Diff is proportional to the number of changes rather
+// than to the size of the maps.
+func Diff[K any, V any](old, cur *Map[K, V]) iters.Iterator[K] {
+	return goslices.Iter(cur.trie.Diff(old.trie))
+}
+
+// Builder builds a persistent map by mutating it in place, which is faster
+// than creating a new version for every update. A builder is not safe for
+// concurrent use.
+type Builder[K any, V any] struct {
+	tr *hamt.Transient[K, V]
+}
+
+// NewBuilder returns a builder initialized with the elements of map m. The
+// given map is not changed by the builder.
+// This function is O(1).
+func NewBuilder[K any, V any](m *Map[K, V]) *Builder[K, V] {
+	return &Builder[K, V]{
+		tr: m.trie.Transient(),
+	}
+}
+
+// Len returns the number of elements in the builder. This function is O(1).
+func (b *Builder[K, V]) Len() int {
+	return b.tr.Len()
+}
+
+// Get returns the value associated with key k. The boolean value is false if
+// the key is not in the builder.
+// This function is O(log32(n)), where n is size of the builder.
+func (b *Builder[K, V]) Get(k K) (v V, ok bool) {
+	if e := b.tr.Get(k); e != nil {
+		return e.Value, true
+	}
+	return
+}
+
+// Put associates key k with value v.
+// This function is O(log32(n)), where n is size of the builder.
+func (b *Builder[K, V]) Put(k K, v V) {
+	b.tr.Put(k, v)
+}
+
+// Delete deletes key k and returns true if the key was in the builder.
+// This function is O(log32(n)), where n is size of the builder.
+func (b *Builder[K, V]) Delete(k K) bool {
+	return b.tr.Delete(k)
+}
+
+// Map returns the current content of the builder as a persistent map. The
+// builder can still be used afterwards, and later updates do not change the
+// returned map.
+// This function is O(1).
+func (b *Builder[K, V]) Map() *Map[K, V] {
+	return &Map[K, V]{
+		trie: b.tr.Persistent(),
+	}
+}
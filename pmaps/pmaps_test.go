@@ -0,0 +1,157 @@
+package pmaps
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/gomaps"
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+func hashInt(v int) uint64 {
+	return uint64(v) * 0x9E3779B97F4A7C15
+}
+
+// hashCollide maps many keys to the same hash, so that buckets are used.
+func hashCollide(v int) uint64 {
+	return uint64(v % 7)
+}
+
+func toMap[K comparable, V any](m *Map[K, V]) map[K]V {
+	return gomaps.FromIter[K, V](Iter(m))
+}
+
+func TestPutGetDelete(t *testing.T) {
+	for _, hash := range []gcl.HashFn[int]{hashInt, hashCollide} {
+		r := rand.New(rand.NewSource(1))
+		m := New[int, int](hash, gcl.Equal[int])
+		ref := map[int]int{}
+		var versions []*Map[int, int]
+		var refs []map[int]int
+		for i := 0; i < 3000; i++ {
+			k := r.Intn(500)
+			if r.Intn(3) == 0 {
+				m = Delete(m, k)
+				delete(ref, k)
+			} else {
+				m = Put(m, k, i)
+				ref[k] = i
+			}
+			if i%100 == 0 {
+				versions = append(versions, m)
+				refs = append(refs, maps.Clone(ref))
+			}
+		}
+		if Len(m) != len(ref) || !maps.Equal(toMap(m), ref) {
+			t.Fatalf("map has %v elements, want %v", Len(m), len(ref))
+		}
+		for k := 0; k < 500; k++ {
+			v, ok := Get(m, k)
+			rv, rok := ref[k]
+			if v != rv || ok != rok || Contains(m, k) != rok {
+				t.Fatalf("Get(m, %v) = %v, %v, want = %v, %v", k, v, ok, rv, rok)
+			}
+		}
+		for i, v := range versions {
+			if !maps.Equal(toMap(v), refs[i]) || Len(v) != len(refs[i]) {
+				t.Fatalf("version %v of the map was changed", i)
+			}
+		}
+	}
+}
+
+func TestDeleteMissing(t *testing.T) {
+	m := Put(New[int, string](hashInt, gcl.Equal[int]), 1, "a")
+	if Delete(m, 2) != m {
+		t.Error("deleting a missing key must return the same map")
+	}
+	empty := Delete(m, 1)
+	if Len(empty) != 0 || Iter(empty).HasNext() || Len(m) != 1 {
+		t.Errorf("got %v, %v, want [], [1:a]", empty, m)
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	base := FromIter[int, int](gomaps.Iter(map[int]int{1: 1, 2: 2}), hashCollide, gcl.Equal[int])
+	b := NewBuilder(base)
+	for i := 0; i < 100; i++ {
+		b.Put(i, i*10)
+	}
+	b.Delete(50)
+	m1 := b.Map()
+	b.Put(1000, 1)
+	b.Delete(1)
+	m2 := b.Map()
+	if Len(base) != 2 || Len(m1) != 99 || Len(m2) != 99 || b.Len() != 99 {
+		t.Errorf("Len = %v, %v, %v, want = 2, 99, 99", Len(base), Len(m1), Len(m2))
+	}
+	if v, _ := Get(base, 2); v != 2 {
+		t.Error("a builder must not change its base map")
+	}
+	if v, ok := Get(m1, 1); !ok || v != 10 || Contains(m1, 1000) || Contains(m1, 50) {
+		t.Error("later builder updates must not change a built map")
+	}
+	if _, ok := b.Get(1); ok || !Contains(m2, 1000) {
+		t.Error("wrong builder content")
+	}
+}
+
+func keySet(it iters.Iterator[int]) []int {
+	res := goslices.FromIter(it)
+	slices.Sort(res)
+	return res
+}
+
+func TestDiff(t *testing.T) {
+	for _, hash := range []gcl.HashFn[int]{hashInt, hashCollide} {
+		b := NewBuilder(New[int, int](hash, gcl.Equal[int]))
+		for i := 0; i < 1000; i++ {
+			b.Put(i, i)
+		}
+		old := b.Map()
+		m := Put(old, 5, 50)
+		m = Put(m, 1000, 1)
+		m = Delete(m, 7)
+		m = Delete(m, 8)
+		m = Put(m, 8, 8)
+		if got, want := keySet(Diff(old, m)), []int{5, 7, 8, 1000}; !slices.Equal(got, want) {
+			t.Errorf("Diff = %v, want %v", got, want)
+		}
+		if got, want := keySet(Diff(m, old)), []int{5, 7, 8, 1000}; !slices.Equal(got, want) {
+			t.Errorf("reverse Diff = %v, want %v", got, want)
+		}
+		if Diff(old, old).HasNext() {
+			t.Error("Diff of a map with itself must be empty")
+		}
+		if got := keySet(Diff(New[int, int](hash, gcl.Equal[int]), old)); len(got) != 1000 {
+			t.Errorf("Diff from the empty map has %v keys, want 1000", len(got))
+		}
+	}
+}
+
+func TestConcurrentReads(t *testing.T) {
+	m := New[int, int](hashInt, gcl.Equal[int])
+	for i := 0; i < 100; i++ {
+		m = Put(m, i, i)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			mine := m
+			for i := 0; i < 100; i++ {
+				mine = Put(mine, i, g)
+				if v, _ := Get(m, i); v != i {
+					t.Errorf("Get(m, %v) = %v, want = %v", i, v, i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
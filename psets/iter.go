@@ -0,0 +1,18 @@
+package psets
+
+import (
+	"github.com/shayanh/gcl/internal/hamt"
+)
+
+// Iterator is an iterator over the elements of a persistent set.
+type Iterator[T any] struct {
+	it *hamt.Iterator[T, struct{}]
+}
+
+func (it *Iterator[T]) HasNext() bool {
+	return it.it.HasNext()
+}
+
+func (it *Iterator[T]) Next() T {
+	return it.it.Next().Key
+}
@@ -0,0 +1,162 @@
+// Package psets provides a persistent hash set and various functions useful
+// with persistent sets of any type.
+//
+// A persistent set is immutable. Add and Remove return a new version of the
+// set and leave the given one unchanged. Versions share the parts of their
+// structure that did not change, so a new version costs O(log32(n)) time and
+// memory. Because nothing is ever mutated, sets can be shared between
+// goroutines without locks. For bulk updates, use a Builder.
+package psets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/internal/hamt"
+	"github.com/shayanh/gcl/iters"
+)
+
+// Set is a persistent hash set implemented as a hash array mapped trie
+// (HAMT).
+type Set[T any] struct {
+	trie hamt.Trie[T, struct{}]
+}
+
+// New creates a new set that uses the given hash and equal functions,
+// containing the given elements, and returns a pointer to it. Elements that
+// are equal according to `eq` must have the same hash.
+func New[T any](hash gcl.HashFn[T], eq gcl.EqualFn[T, T], elems ...T) *Set[T] {
+	return FromIter[T](goslices.Iter(elems), hash, eq)
+}
+
+// FromIter builds a new set from the given iterator, using the given hash and
+// equal functions. FromIter uses a Builder, so it does not create
+// intermediate versions.
+func FromIter[T any](it iters.Iterator[T], hash gcl.HashFn[T], eq gcl.EqualFn[T, T]) *Set[T] {
+	b := NewBuilder(&Set[T]{trie: hamt.New[T, struct{}](hash, eq)})
+	for it.HasNext() {
+		b.Add(it.Next())
+	}
+	return b.Set()
+}
+
+func (s *Set[T]) String() string {
+	var b strings.Builder
+	b.WriteString("psets.Set[")
+	it := Iter(s)
+	for it.HasNext() {
+		v := it.Next()
+		fmt.Fprintf(&b, "%v", v)
+		if it.HasNext() {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Len returns the number of elements in the given set. This function is O(1).
+func Len[T any](s *Set[T]) int {
+	return s.trie.Len()
+}
+
+// Contains tests whether v is in the set.
+// This function is O(log32(n)), where n is size of the set.
+func Contains[T any](s *Set[T], v T) bool {
+	return s.trie.Get(v) != nil
+}
+
+// Add returns a new version of the set that contains v. If v is already in
+// the set, s itself is returned.
+// This function is O(log32(n)), where n is size of the set.
+func Add[T any](s *Set[T], v T) *Set[T] {
+	if Contains(s, v) {
+		return s
+	}
+	return &Set[T]{
+		trie: s.trie.Put(v, struct{}{}),
+	}
+}
+
+// Remove returns a new version of the set without v. If v is not in the set,
+// s itself is returned.
+// This function is O(log32(n)), where n is size of the set.
+func Remove[T any](s *Set[T], v T) *Set[T] {
+	trie := s.trie.Delete(v)
+	if trie.Len() == s.trie.Len() {
+		return s
+	}
+	return &Set[T]{
+		trie: trie,
+	}
+}
+
+// Iter returns an iterator over the elements of the given set. The iteration
+// order is determined by the hashes of the elements.
+func Iter[T any](s *Set[T]) *Iterator[T] {
+	return &Iterator[T]{
+		it: s.trie.Iter(),
+	}
+}
+
+// Diff returns an iterator over the elements that are added to or removed
+// from set cur compared to set old. Both sets must use the same hash and
+// equal functions. The parts that old and cur share are skipped, so for two
+// versions of a set, Diff is proportional to the number of changes rather
+// than to the size of the sets.
+func Diff[T any](old, cur *Set[T]) iters.Iterator[T] {
+	return goslices.Iter(cur.trie.Diff(old.trie))
+}
+
+// Builder builds a persistent set by mutating it in place, which is faster
+// than creating a new version for every update. A builder is not safe for
+// concurrent use.
+type Builder[T any] struct {
+	tr *hamt.Transient[T, struct{}]
+}
+
+// NewBuilder returns a builder initialized with the elements of set s. The
+// given set is not changed by the builder.
+// This function is O(1).
+func NewBuilder[T any](s *Set[T]) *Builder[T] {
+	return &Builder[T]{
+		tr: s.trie.Transient(),
+	}
+}
+
+// Len returns the number of elements in the builder. This function is O(1).
+func (b *Builder[T]) Len() int {
+	return b.tr.Len()
+}
+
+// Contains tests whether v is in the builder.
+// This function is O(log32(n)), where n is size of the builder.
+func (b *Builder[T]) Contains(v T) bool {
+	return b.tr.Get(v) != nil
+}
+
+// Add adds v to the builder.
+// This function is O(log32(n)), where n is size of the builder.
+func (b *Builder[T]) Add(v T) {
+	if !b.Contains(v) {
+		b.tr.Put(v, struct{}{})
+	}
+}
+
+// Remove removes v and returns true if it was in the builder.
+// This function is O(log32(n)), where n is size of the builder.
+func (b *Builder[T]) Remove(v T) bool {
+	return b.tr.Delete(v)
+}
+
+// Set returns the current content of the builder as a persistent set. The
+// builder can still be used afterwards, and later updates do not change the
+// returned set.
+// This function is O(1).
+func (b *Builder[T]) Set() *Set[T] {
+	return &Set[T]{
+		trie: b.tr.Persistent(),
+	}
+}
@@ -0,0 +1,85 @@
+package psets
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/goslices"
+	"golang.org/x/exp/slices"
+)
+
+func hashInt(v int) uint64 {
+	return uint64(v) * 0x9E3779B97F4A7C15
+}
+
+func hashCollide(v int) uint64 {
+	return uint64(v % 5)
+}
+
+func elems(s *Set[int]) []int {
+	res := goslices.FromIter[int](Iter(s))
+	slices.Sort(res)
+	return res
+}
+
+func TestAddRemove(t *testing.T) {
+	for _, hash := range []gcl.HashFn[int]{hashInt, hashCollide} {
+		r := rand.New(rand.NewSource(1))
+		s := New(hash, gcl.Equal[int])
+		ref := map[int]bool{}
+		for i := 0; i < 2000; i++ {
+			v := r.Intn(300)
+			if r.Intn(3) == 0 {
+				s = Remove(s, v)
+				delete(ref, v)
+			} else {
+				s = Add(s, v)
+				ref[v] = true
+			}
+		}
+		var want []int
+		for v := range ref {
+			want = append(want, v)
+		}
+		slices.Sort(want)
+		if got := elems(s); !slices.Equal(got, want) || Len(s) != len(want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		for v := 0; v < 300; v++ {
+			if Contains(s, v) != ref[v] {
+				t.Errorf("Contains(s, %v) = %v, want = %v", v, Contains(s, v), ref[v])
+			}
+		}
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	s1 := New(hashInt, gcl.Equal[int], 1, 2, 3)
+	s2 := Add(s1, 4)
+	s3 := Remove(s2, 1)
+	if !slices.Equal(elems(s1), []int{1, 2, 3}) || !slices.Equal(elems(s2), []int{1, 2, 3, 4}) || !slices.Equal(elems(s3), []int{2, 3, 4}) {
+		t.Errorf("got %v, %v, %v", s1, s2, s3)
+	}
+	if Add(s1, 1) != s1 || Remove(s1, 5) != s1 {
+		t.Error("adding an existing or removing a missing element must return the same set")
+	}
+}
+
+func TestBuilderDiff(t *testing.T) {
+	old := New(hashCollide, gcl.Equal[int], 1, 2, 3, 4, 5, 6)
+	b := NewBuilder(old)
+	b.Add(7)
+	b.Add(1)
+	b.Remove(2)
+	s := b.Set()
+	b.Add(8)
+	if !slices.Equal(elems(s), []int{1, 3, 4, 5, 6, 7}) || b.Len() != 7 || Len(old) != 6 {
+		t.Errorf("got %v, want [1 3 4 5 6 7]", s)
+	}
+	got := goslices.FromIter(Diff(old, s))
+	slices.Sort(got)
+	if want := []int{2, 7}; !slices.Equal(got, want) {
+		t.Errorf("Diff = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,130 @@
+// Package syncs provides containers that are safe for concurrent use by
+// multiple goroutines.
+//
+// The other containers of gcl are not safe for concurrent use. List and Map
+// wrap them with a sync.RWMutex, and their iterators work on snapshots, so
+// iterating never blocks writers. Queue is a lock-free bounded queue.
+package syncs
+
+import (
+	"sync"
+
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/lists"
+)
+
+// List is a doubly linked list guarded by a read-write mutex.
+type List[T any] struct {
+	mu  sync.RWMutex
+	lst *lists.List[T]
+}
+
+// NewList creates a new list containing the given elements and returns a
+// pointer to it.
+func NewList[T any](elems ...T) *List[T] {
+	return &List[T]{
+		lst: lists.New(elems...),
+	}
+}
+
+// Len returns size of the list. This function is O(1).
+func (l *List[T]) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return lists.Len(l.lst)
+}
+
+// PushBack appends the given elements to the back of the list.
+// This function is O(len(elems)).
+func (l *List[T]) PushBack(elems ...T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lists.PushBack(l.lst, elems...)
+}
+
+// PushFront appends the given elements to the beginning of the list.
+// This function is O(len(elems)).
+func (l *List[T]) PushFront(elems ...T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lists.PushFront(l.lst, elems...)
+}
+
+// PopBack deletes the last element of the list and returns it. The boolean
+// value is false if the list is empty.
+// This function is O(1).
+func (l *List[T]) PopBack() (v T, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lists.Len(l.lst) == 0 {
+		return
+	}
+	v = lists.Back(l.lst)
+	lists.PopBack(l.lst)
+	return v, true
+}
+
+// PopFront deletes the first element of the list and returns it. The boolean
+// value is false if the list is empty.
+// This function is O(1).
+func (l *List[T]) PopFront() (v T, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lists.Len(l.lst) == 0 {
+		return
+	}
+	v = lists.Front(l.lst)
+	lists.PopFront(l.lst)
+	return v, true
+}
+
+// Front returns the first element of the list. The boolean value is false if
+// the list is empty.
+// This function is O(1).
+func (l *List[T]) Front() (v T, ok bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if lists.Len(l.lst) == 0 {
+		return
+	}
+	return lists.Front(l.lst), true
+}
+
+// Back returns the last element of the list. The boolean value is false if
+// the list is empty.
+// This function is O(1).
+func (l *List[T]) Back() (v T, ok bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if lists.Len(l.lst) == 0 {
+		return
+	}
+	return lists.Back(l.lst), true
+}
+
+// View calls fn with the underlying list while holding a read lock. fn must
+// not modify the list or keep any reference to it after returning.
+func (l *List[T]) View(fn func(*lists.List[T])) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	fn(l.lst)
+}
+
+// Update calls fn with the underlying list while holding a write lock, so
+// that several operations can be applied atomically. fn must not keep any
+// reference to the list after returning.
+func (l *List[T]) Update(fn func(*lists.List[T])) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fn(l.lst)
+}
+
+// Iter returns an iterator over a snapshot of the list elements. The
+// snapshot is taken when Iter is called, and later modifications of the list
+// are not visible to the iterator.
+// This function is O(n), where n is length of the list.
+func (l *List[T]) Iter() *goslices.FrwIter[T] {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return goslices.Iter(goslices.FromIter[T](lists.Iter(l.lst)))
+}
@@ -0,0 +1,70 @@
+package syncs
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shayanh/gcl/iters"
+	"github.com/shayanh/gcl/lists"
+)
+
+func TestList(t *testing.T) {
+	l := NewList(2, 3)
+	l.PushFront(1)
+	l.PushBack(4)
+	if v, ok := l.Front(); !ok || v != 1 {
+		t.Errorf("l.Front() = %v, %v, want = 1, true", v, ok)
+	}
+	if v, ok := l.PopBack(); !ok || v != 4 || l.Len() != 3 {
+		t.Errorf("l.PopBack() = %v, %v, want = 4, true", v, ok)
+	}
+	it := l.Iter()
+	l.Update(func(lst *lists.List[int]) {
+		lists.MapInPlace(lst, func(v int) int { return v * 10 })
+	})
+	if got := iters.Sum[int](it); got != 6 {
+		t.Errorf("snapshot iterator sum = %v, want = 6", got)
+	}
+	l.View(func(lst *lists.List[int]) {
+		if !lists.Equal(lst, lists.New(10, 20, 30)) {
+			t.Errorf("got %v, want [10 20 30]", lst)
+		}
+	})
+	for l.Len() > 0 {
+		l.PopFront()
+	}
+	if _, ok := l.PopFront(); ok {
+		t.Error("PopFront on an empty list must fail")
+	}
+	if _, ok := l.Back(); ok {
+		t.Error("Back on an empty list must fail")
+	}
+}
+
+func TestListStress(t *testing.T) {
+	const workers, perWorker = 8, 1000
+	l := NewList[int]()
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				switch i % 4 {
+				case 0:
+					l.PushFront(i)
+				case 1:
+					l.PushBack(i)
+				case 2:
+					l.PopFront()
+				default:
+					iters.Len[int](l.Iter())
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	if n := l.Len(); n != workers*perWorker/4 {
+		t.Errorf("l.Len() = %v, want = %v", n, workers*perWorker/4)
+	}
+}
@@ -0,0 +1,90 @@
+package syncs
+
+import (
+	"sync"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/hmaps"
+)
+
+// Map is a hash map guarded by a read-write mutex. Like hmaps.Map, it uses
+// user-supplied hash and equal functions for keys.
+type Map[K any, V any] struct {
+	mu sync.RWMutex
+	m  *hmaps.Map[K, V]
+}
+
+// NewMap creates a new empty map that uses the given hash and equal functions
+// for keys and returns a pointer to it.
+func NewMap[K any, V any](hash gcl.HashFn[K], eq gcl.EqualFn[K, K]) *Map[K, V] {
+	return &Map[K, V]{
+		m: hmaps.New[K, V](hash, eq),
+	}
+}
+
+// Len returns the number of elements in the map. This function is O(1).
+func (m *Map[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return hmaps.Len(m.m)
+}
+
+// Get returns the value associated with key k. The boolean value is false if
+// the key is not in the map.
+func (m *Map[K, V]) Get(k K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return hmaps.Get(m.m, k)
+}
+
+// Contains tests whether key k is in the map.
+func (m *Map[K, V]) Contains(k K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return hmaps.Contains(m.m, k)
+}
+
+// Put associates key k with value v.
+func (m *Map[K, V]) Put(k K, v V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	hmaps.Put(m.m, k, v)
+}
+
+// GetOrInsert returns the value associated with key k if it exists.
+// Otherwise, it associates k with v and returns v. The boolean value is true
+// if the key was already in the map. The check and the insertion happen
+// atomically.
+func (m *Map[K, V]) GetOrInsert(k K, v V) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return hmaps.GetOrInsert(m.m, k, v)
+}
+
+// Update replaces the value associated with key k by the result of fn
+// applied to it, atomically. It returns false if the key is not in the map.
+// fn must not use the map.
+func (m *Map[K, V]) Update(k K, fn func(V) V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return hmaps.Update(m.m, k, fn)
+}
+
+// Delete deletes key k from the map and returns true if the key was in the
+// map.
+func (m *Map[K, V]) Delete(k K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return hmaps.Delete(m.m, k)
+}
+
+// Iter returns an iterator over a snapshot of the map elements, in the
+// iteration order of hmaps.Map. The snapshot is taken when Iter is called,
+// and later modifications of the map are not visible to the iterator.
+// This function is O(n), where n is size of the map.
+func (m *Map[K, V]) Iter() *goslices.FrwIter[gcl.MapElem[K, V]] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return goslices.Iter(goslices.FromIter[gcl.MapElem[K, V]](hmaps.Iter(m.m)))
+}
@@ -0,0 +1,61 @@
+package syncs
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/iters"
+)
+
+func hashInt(v int) uint64 {
+	return uint64(v)
+}
+
+func TestMap(t *testing.T) {
+	m := NewMap[int, string](hashInt, gcl.Equal[int])
+	m.Put(1, "a")
+	if v, ok := m.GetOrInsert(1, "b"); !ok || v != "a" {
+		t.Errorf("m.GetOrInsert(1, b) = %v, %v, want = a, true", v, ok)
+	}
+	if v, ok := m.GetOrInsert(2, "b"); ok || v != "b" {
+		t.Errorf("m.GetOrInsert(2, b) = %v, %v, want = b, false", v, ok)
+	}
+	it := m.Iter()
+	m.Update(1, func(v string) string { return v + v })
+	if v, _ := m.Get(1); v != "aa" || !m.Contains(2) || m.Len() != 2 {
+		t.Errorf("m.Get(1) = %v, want = aa", v)
+	}
+	if !m.Delete(2) || m.Delete(2) {
+		t.Error("wrong Delete behavior")
+	}
+	if n := iters.Len[gcl.MapElem[int, string]](it); n != 2 || it.Next().Value != "a" {
+		t.Errorf("snapshot iterator must see the old content")
+	}
+}
+
+func TestMapStress(t *testing.T) {
+	const workers, keys = 8, 100
+	m := NewMap[int, int](hashInt, gcl.Equal[int])
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for k := 0; k < keys; k++ {
+				m.GetOrInsert(k, 0)
+				m.Update(k, func(v int) int { return v + 1 })
+				m.Get(k)
+				if k%10 == 0 {
+					m.Iter()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	for k := 0; k < keys; k++ {
+		if v, _ := m.Get(k); v != workers {
+			t.Errorf("m.Get(%v) = %v, want = %v", k, v, workers)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package syncs
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// cacheLinePad separates fields that are written by different goroutines, so
+// that they don't share a cache line.
+type cacheLinePad struct {
+	_ [64]byte
+}
+
+type cell[T any] struct {
+	// seq tells the state of the cell for the position that maps to it. The
+	// cell is free to be pushed at position pos if seq == pos, and holds a
+	// value to be popped at position pos if seq == pos+1.
+	seq   uintptr
+	value T
+}
+
+// Queue is a lock-free bounded multi-producer multi-consumer FIFO queue. It
+// is implemented as a ring buffer of cells with sequence numbers, as
+// described by Dmitry Vyukov. Producers and consumers only contend on one
+// atomic counter each, and they never block each other unless the queue is
+// full or empty.
+type Queue[T any] struct {
+	_    cacheLinePad
+	head uintptr // next position to pop
+	_    cacheLinePad
+	tail uintptr // next position to push
+	_    cacheLinePad
+	buf  []cell[T]
+	mask uintptr
+}
+
+// NewQueue creates a new empty queue that can hold at least `capacity`
+// elements and returns a pointer to it. The capacity is rounded up to a power
+// of two, and to at least two, since a single cell cannot tell a full queue
+// from an empty one. It requires capacity to be positive, otherwise it panics.
+func NewQueue[T any](capacity int) *Queue[T] {
+	require(capacity > 0, "capacity must be positive")
+	size := 2
+	for size < capacity {
+		size <<= 1
+	}
+	q := &Queue[T]{
+		buf:  make([]cell[T], size),
+		mask: uintptr(size - 1),
+	}
+	for i := range q.buf {
+		q.buf[i].seq = uintptr(i)
+	}
+	return q
+}
+
+// Cap returns the capacity of the queue. This function is O(1).
+func (q *Queue[T]) Cap() int {
+	return len(q.buf)
+}
+
+// Len returns the number of elements in the queue. When other goroutines are
+// pushing or popping at the same time, the result is only an estimate.
+// This function is O(1).
+func (q *Queue[T]) Len() int {
+	head := atomic.LoadUintptr(&q.head)
+	tail := atomic.LoadUintptr(&q.tail)
+	n := int(tail - head)
+	if n < 0 {
+		return 0
+	}
+	if n > len(q.buf) {
+		return len(q.buf)
+	}
+	return n
+}
+
+// TryPush adds v to the back of the queue if the queue is not full. It
+// returns false if the queue is full. TryPush never blocks.
+func (q *Queue[T]) TryPush(v T) bool {
+	pos := atomic.LoadUintptr(&q.tail)
+	for {
+		c := &q.buf[pos&q.mask]
+		seq := atomic.LoadUintptr(&c.seq)
+		switch dif := int(seq - pos); {
+		case dif == 0:
+			if atomic.CompareAndSwapUintptr(&q.tail, pos, pos+1) {
+				c.value = v
+				atomic.StoreUintptr(&c.seq, pos+1)
+				return true
+			}
+			pos = atomic.LoadUintptr(&q.tail)
+		case dif < 0:
+			return false
+		default:
+			pos = atomic.LoadUintptr(&q.tail)
+		}
+	}
+}
+
+// TryPop removes and returns the element at the front of the queue if the
+// queue is not empty. The boolean value is false if the queue is empty.
+// TryPop never blocks.
+func (q *Queue[T]) TryPop() (v T, ok bool) {
+	pos := atomic.LoadUintptr(&q.head)
+	for {
+		c := &q.buf[pos&q.mask]
+		seq := atomic.LoadUintptr(&c.seq)
+		switch dif := int(seq - (pos + 1)); {
+		case dif == 0:
+			if atomic.CompareAndSwapUintptr(&q.head, pos, pos+1) {
+				v = c.value
+				var zero T
+				c.value = zero
+				atomic.StoreUintptr(&c.seq, pos+q.mask+1)
+				return v, true
+			}
+			pos = atomic.LoadUintptr(&q.head)
+		case dif < 0:
+			return
+		default:
+			pos = atomic.LoadUintptr(&q.head)
+		}
+	}
+}
+
+// Push adds v to the back of the queue. If the queue is full, Push waits
+// until there is room or ctx is done, in which case it returns the context
+// error.
+func (q *Queue[T]) Push(ctx context.Context, v T) error {
+	for attempt := 0; !q.TryPush(v); attempt++ {
+		if err := backoff(ctx, attempt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pop removes and returns the element at the front of the queue. If the
+// queue is empty, Pop waits until an element is pushed or ctx is done, in
+// which case it returns the context error.
+func (q *Queue[T]) Pop(ctx context.Context) (T, error) {
+	for attempt := 0; ; attempt++ {
+		if v, ok := q.TryPop(); ok {
+			return v, nil
+		}
+		if err := backoff(ctx, attempt); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+const (
+	spinAttempts = 16
+	maxSleep     = time.Millisecond
+)
+
+// backoff waits before the next attempt of a blocking operation. It yields
+// the processor for the first few attempts and then sleeps for exponentially
+// growing periods, up to maxSleep. It returns the context error if ctx is
+// done.
+func backoff(ctx context.Context, attempt int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if attempt < spinAttempts {
+		runtime.Gosched()
+		return nil
+	}
+	d := maxSleep
+	if shift := attempt - spinAttempts; shift < 10 {
+		d = time.Microsecond << shift
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func require(check bool, failMsg string) {
+	if !check {
+		panic(failMsg)
+	}
+}
@@ -0,0 +1,131 @@
+package syncs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueueTryPushPop(t *testing.T) {
+	q := NewQueue[int](3)
+	if q.Cap() != 4 {
+		t.Errorf("q.Cap() = %v, want = 4", q.Cap())
+	}
+	for i := 0; i < 4; i++ {
+		if !q.TryPush(i) {
+			t.Fatalf("TryPush(%v) failed on a non-full queue", i)
+		}
+	}
+	if q.TryPush(4) || q.Len() != 4 {
+		t.Error("TryPush must fail on a full queue")
+	}
+	for i := 0; i < 4; i++ {
+		if v, ok := q.TryPop(); !ok || v != i {
+			t.Errorf("TryPop() = %v, %v, want = %v, true", v, ok, i)
+		}
+	}
+	if _, ok := q.TryPop(); ok || q.Len() != 0 {
+		t.Error("TryPop must fail on an empty queue")
+	}
+	// Wrap around the ring several times.
+	for i := 0; i < 100; i++ {
+		q.TryPush(i)
+		if v, _ := q.TryPop(); v != i {
+			t.Fatalf("TryPop() = %v, want = %v", v, i)
+		}
+	}
+}
+
+func TestQueueBlocking(t *testing.T) {
+	q := NewQueue[int](1)
+	ctx := context.Background()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Push(ctx, 1)
+	}()
+	if v, err := q.Pop(ctx); err != nil || v != 1 {
+		t.Errorf("Pop() = %v, %v, want = 1, nil", v, err)
+	}
+
+	if q.Cap() != 2 {
+		t.Errorf("q.Cap() = %v, want = 2", q.Cap())
+	}
+	q.TryPush(2)
+	q.TryPush(3)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := q.Push(ctx, 4); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Push on a full queue = %v, want = %v", err, context.DeadlineExceeded)
+	}
+	q.TryPop()
+	q.TryPop()
+	if _, err := q.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Pop on an empty queue = %v, want = %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestQueueStress(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 5000
+	q := NewQueue[int](64)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := q.Push(ctx, p*perProducer+i); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(p)
+	}
+
+	results := make([][]int, consumers)
+	var cwg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func(c int) {
+			defer cwg.Done()
+			for i := 0; i < producers*perProducer/consumers; i++ {
+				v, err := q.Pop(ctx)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				results[c] = append(results[c], v)
+			}
+		}(c)
+	}
+	wg.Wait()
+	cwg.Wait()
+
+	seen := make([]bool, producers*perProducer)
+	for _, res := range results {
+		last := make([]int, producers)
+		for p := range last {
+			last[p] = -1
+		}
+		for _, v := range res {
+			if seen[v] {
+				t.Fatalf("value %v popped twice", v)
+			}
+			seen[v] = true
+			// Values of a single producer must come out in order.
+			if p := v / perProducer; v <= last[p] {
+				t.Fatalf("value %v popped after %v", v, last[p])
+			} else {
+				last[p] = v
+			}
+		}
+	}
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("value %v is lost", v)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package tmaps
+
+import (
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/internal/avl"
+)
+
+func require(check bool, failMsg string) {
+	if !check {
+		panic(failMsg)
+	}
+}
+
+// FrwIter is a tree map forward iterator. It yields the map elements in
+// ascending key order.
+type FrwIter[K any, V any] struct {
+	impl *avl.Iterator[K, V]
+}
+
+func (it *FrwIter[K, V]) HasNext() bool {
+	return it.impl.HasNext()
+}
+
+func (it *FrwIter[K, V]) Next() gcl.MapElem[K, V] {
+	require(it.HasNext(), "iterator must have next")
+	return toElem(it.impl.Next())
+}
+
+// RevIter is a tree map reverse iterator. It yields the map elements in
+// descending key order.
+type RevIter[K any, V any] struct {
+	impl *avl.Iterator[K, V]
+}
+
+func (it *RevIter[K, V]) HasNext() bool {
+	return it.impl.HasNext()
+}
+
+func (it *RevIter[K, V]) Next() gcl.MapElem[K, V] {
+	require(it.HasNext(), "iterator must have next")
+	return toElem(it.impl.Next())
+}
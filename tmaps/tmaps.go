@@ -0,0 +1,178 @@
+// Package tmaps provides an ordered tree map and various functions useful
+// with ordered maps of any type.
+//
+// A map is not safe for concurrent use without external synchronization.
+package tmaps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/internal/avl"
+	"github.com/shayanh/gcl/iters"
+	"golang.org/x/exp/constraints"
+)
+
+// Map is an ordered map implemented as a balanced binary search tree (AVL
+// tree). Keys are kept in ascending order as determined by the compare
+// function of the map.
+type Map[K any, V any] struct {
+	tree *avl.Tree[K, V]
+}
+
+// New creates a new empty map of any ordered key type and returns a pointer to
+// it.
+func New[K constraints.Ordered, V any]() *Map[K, V] {
+	return NewFunc[K, V](gcl.Compare[K])
+}
+
+// NewFunc creates a new empty map whose keys are ordered by the `cmp` function
+// and returns a pointer to it.
+func NewFunc[K any, V any](cmp gcl.CompareFn[K, K]) *Map[K, V] {
+	return &Map[K, V]{
+		tree: avl.New[K, V](cmp),
+	}
+}
+
+// FromIter builds a new map of any ordered key type from the given iterator.
+// For duplicate keys, the last value wins.
+func FromIter[K constraints.Ordered, V any](it iters.Iterator[gcl.MapElem[K, V]]) *Map[K, V] {
+	m := New[K, V]()
+	for it.HasNext() {
+		elem := it.Next()
+		Put(m, elem.Key, elem.Value)
+	}
+	return m
+}
+
+func (m *Map[K, V]) String() string {
+	var b strings.Builder
+	b.WriteString("tmaps.Map[")
+	it := Iter(m)
+	for it.HasNext() {
+		elem := it.Next()
+		fmt.Fprintf(&b, "%v:%v", elem.Key, elem.Value)
+		if it.HasNext() {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Len returns the number of elements in the given map. This function is O(1).
+func Len[K any, V any](m *Map[K, V]) int {
+	return m.tree.Len()
+}
+
+// Get returns the value of key k. The returned boolean value indicates if k
+// is present in the map.
+// This function is O(log(n)), where n is size of the map.
+func Get[K any, V any](m *Map[K, V], k K) (v V, ok bool) {
+	if n := m.tree.Find(k); n != nil {
+		return n.Value, true
+	}
+	return
+}
+
+// Contains tests whether the given map m has key k.
+// This function is O(log(n)), where n is size of the map.
+func Contains[K any, V any](m *Map[K, V], k K) bool {
+	return m.tree.Find(k) != nil
+}
+
+// Put sets the value of key k to v. If k is already present, its value is
+// replaced.
+// This function is O(log(n)), where n is size of the map.
+func Put[K any, V any](m *Map[K, V], k K, v V) {
+	m.tree.Put(k, v)
+}
+
+// Delete deletes key k from the map. The returned boolean value indicates if
+// k was present in the map.
+// This function is O(log(n)), where n is size of the map.
+func Delete[K any, V any](m *Map[K, V], k K) bool {
+	return m.tree.Delete(k)
+}
+
+// Min returns the element with the smallest key. It requires the map to be
+// non-empty, otherwise it panics.
+// This function is O(log(n)), where n is size of the map.
+func Min[K any, V any](m *Map[K, V]) gcl.MapElem[K, V] {
+	require(Len(m) > 0, "map cannot be empty")
+	return toElem(m.tree.Min())
+}
+
+// Max returns the element with the largest key. It requires the map to be
+// non-empty, otherwise it panics.
+// This function is O(log(n)), where n is size of the map.
+func Max[K any, V any](m *Map[K, V]) gcl.MapElem[K, V] {
+	require(Len(m) > 0, "map cannot be empty")
+	return toElem(m.tree.Max())
+}
+
+// Floor returns the element with the largest key less than or equal to k. The
+// returned boolean value indicates if such an element exists.
+// This function is O(log(n)), where n is size of the map.
+func Floor[K any, V any](m *Map[K, V], k K) (gcl.MapElem[K, V], bool) {
+	return lookup(m.tree.Floor(k))
+}
+
+// Ceiling returns the element with the smallest key greater than or equal to
+// k. The returned boolean value indicates if such an element exists.
+// This function is O(log(n)), where n is size of the map.
+func Ceiling[K any, V any](m *Map[K, V], k K) (gcl.MapElem[K, V], bool) {
+	return lookup(m.tree.Ceiling(k))
+}
+
+// Iter returns a forward iterator over the elements of the given map in
+// ascending key order.
+// Any insertion or deletion in the map invalidates the iterator.
+func Iter[K any, V any](m *Map[K, V]) *FrwIter[K, V] {
+	return &FrwIter[K, V]{
+		impl: m.tree.Iter(),
+	}
+}
+
+// RIter returns a reverse iterator over the elements of the given map in
+// descending key order.
+// Any insertion or deletion in the map invalidates the iterator.
+func RIter[K any, V any](m *Map[K, V]) *RevIter[K, V] {
+	return &RevIter[K, V]{
+		impl: m.tree.RIter(),
+	}
+}
+
+// Range returns a forward iterator over the elements of the given map with
+// keys in the half-open interval [lo, hi), in ascending key order.
+// Creating the iterator is O(log(n)), where n is size of the map.
+// Any insertion or deletion in the map invalidates the iterator.
+func Range[K any, V any](m *Map[K, V], lo, hi K) *FrwIter[K, V] {
+	return &FrwIter[K, V]{
+		impl: m.tree.Range(lo, hi),
+	}
+}
+
+// Clone returns a copy of the given map. Keys and values are copied using
+// assignment, so this is a shallow clone.
+// This function is O(n), where n is size of the map.
+func Clone[K any, V any](m *Map[K, V]) *Map[K, V] {
+	return &Map[K, V]{
+		tree: m.tree.Clone(),
+	}
+}
+
+func toElem[K any, V any](n *avl.Node[K, V]) gcl.MapElem[K, V] {
+	return gcl.MapElem[K, V]{
+		Key:   n.Key,
+		Value: n.Value,
+	}
+}
+
+func lookup[K any, V any](n *avl.Node[K, V]) (elem gcl.MapElem[K, V], ok bool) {
+	if n == nil {
+		return
+	}
+	return toElem(n), true
+}
@@ -0,0 +1,197 @@
+package tmaps
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/gomaps"
+	"github.com/shayanh/gcl/goslices"
+	"github.com/shayanh/gcl/iters"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+func keys[K any, V any](it iters.Iterator[gcl.MapElem[K, V]]) []K {
+	var res []K
+	for it.HasNext() {
+		res = append(res, it.Next().Key)
+	}
+	return res
+}
+
+func TestPutGetDelete(t *testing.T) {
+	m := New[int, string]()
+	Put(m, 2, "b")
+	Put(m, 1, "a")
+	Put(m, 3, "c")
+	Put(m, 2, "B")
+
+	if Len(m) != 3 {
+		t.Errorf("Len(%v) = %v, want = %v", m, Len(m), 3)
+	}
+	if v, ok := Get(m, 2); !ok || v != "B" {
+		t.Errorf("Get(%v, 2) = (%v, %v), want = (B, true)", m, v, ok)
+	}
+	if _, ok := Get(m, 4); ok {
+		t.Errorf("Get(%v, 4) must not be ok", m)
+	}
+	if !Delete(m, 1) {
+		t.Errorf("Delete(%v, 1) = false, want = true", m)
+	}
+	if Delete(m, 1) {
+		t.Errorf("Delete(%v, 1) = true, want = false", m)
+	}
+	if Contains(m, 1) || Len(m) != 2 {
+		t.Errorf("got %v after Delete, want tmaps.Map[2:B 3:c]", m)
+	}
+}
+
+func TestRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := New[int, int]()
+	ref := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		k := r.Intn(500)
+		if r.Intn(3) == 0 {
+			if got, want := Delete(m, k), contains(ref, k); got != want {
+				t.Fatalf("Delete(m, %v) = %v, want = %v", k, got, want)
+			}
+			delete(ref, k)
+		} else {
+			Put(m, k, i)
+			ref[k] = i
+		}
+	}
+	if Len(m) != len(ref) {
+		t.Fatalf("Len(m) = %v, want = %v", Len(m), len(ref))
+	}
+	want := maps.Keys(ref)
+	slices.Sort(want)
+	if got := keys[int, int](Iter(m)); !slices.Equal(got, want) {
+		t.Fatalf("Iter(m) yielded %v, want %v", got, want)
+	}
+	for k, v := range ref {
+		if got, ok := Get(m, k); !ok || got != v {
+			t.Fatalf("Get(m, %v) = (%v, %v), want = (%v, true)", k, got, ok, v)
+		}
+	}
+}
+
+func contains(m map[int]int, k int) bool {
+	_, ok := m[k]
+	return ok
+}
+
+func TestIter(t *testing.T) {
+	m := FromIter[int, int](goslices.Iter([]gcl.MapElem[int, int]{
+		{Key: 3, Value: 30},
+		{Key: 1, Value: 10},
+		{Key: 2, Value: 20},
+	}))
+	want := goslices.Iter([]gcl.MapElem[int, int]{
+		{Key: 1, Value: 10},
+		{Key: 2, Value: 20},
+		{Key: 3, Value: 30},
+	})
+	if !iters.Equal[gcl.MapElem[int, int]](Iter(m), want) {
+		t.Error("Wrong Iter result")
+	}
+	if got := keys[int, int](RIter(m)); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("RIter(%v) yielded %v, want %v", m, got, []int{3, 2, 1})
+	}
+	if it := Iter(New[int, int]()); it.HasNext() {
+		t.Error("Iter of an empty map must not have next")
+	}
+}
+
+var rangeTests = []struct {
+	lo, hi int
+	want   []int
+}{
+	{0, 100, []int{1, 3, 5, 7, 9}},
+	{3, 7, []int{3, 5}},
+	{2, 8, []int{3, 5, 7}},
+	{4, 5, nil},
+	{9, 10, []int{9}},
+	{10, 20, nil},
+	{5, 1, nil},
+}
+
+func TestRange(t *testing.T) {
+	m := New[int, struct{}]()
+	for _, k := range []int{5, 1, 9, 3, 7} {
+		Put(m, k, struct{}{})
+	}
+	for _, test := range rangeTests {
+		if got := keys[int, struct{}](Range(m, test.lo, test.hi)); !slices.Equal(got, test.want) {
+			t.Errorf("Range(%v, %v, %v) yielded %v, want %v", m, test.lo, test.hi, got, test.want)
+		}
+	}
+}
+
+var floorCeilingTests = []struct {
+	k                  int
+	floor, ceiling     int
+	floorOk, ceilingOk bool
+}{
+	{0, 0, 10, false, true},
+	{10, 10, 10, true, true},
+	{15, 10, 20, true, true},
+	{30, 30, 30, true, true},
+	{35, 30, 0, true, false},
+}
+
+func TestFloorCeiling(t *testing.T) {
+	m := New[int, int]()
+	for _, k := range []int{20, 10, 30} {
+		Put(m, k, k)
+	}
+	for _, test := range floorCeilingTests {
+		if e, ok := Floor(m, test.k); ok != test.floorOk || e.Key != test.floor {
+			t.Errorf("Floor(%v, %v) = (%v, %v), want = (%v, %v)", m, test.k, e.Key, ok, test.floor, test.floorOk)
+		}
+		if e, ok := Ceiling(m, test.k); ok != test.ceilingOk || e.Key != test.ceiling {
+			t.Errorf("Ceiling(%v, %v) = (%v, %v), want = (%v, %v)", m, test.k, e.Key, ok, test.ceiling, test.ceilingOk)
+		}
+	}
+	if Min(m).Key != 10 || Max(m).Key != 30 {
+		t.Errorf("Min(%v), Max(%v) = %v, %v, want = 10, 30", m, m, Min(m).Key, Max(m).Key)
+	}
+}
+
+func TestNewFunc(t *testing.T) {
+	m := NewFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	Put(m, "b", 1)
+	Put(m, "A", 2)
+	Put(m, "B", 3)
+	if got := keys[string, int](Iter(m)); !slices.Equal(got, []string{"A", "b"}) {
+		t.Errorf("Iter(%v) yielded %v, want %v", m, got, []string{"A", "b"})
+	}
+	if v, _ := Get(m, "a"); v != 2 {
+		t.Errorf("Get(%v, a) = %v, want = %v", m, v, 2)
+	}
+}
+
+func TestClone(t *testing.T) {
+	m := New[int, int]()
+	Put(m, 1, 1)
+	cloned := Clone(m)
+	Put(cloned, 2, 2)
+	if Len(m) != 1 || Len(cloned) != 2 {
+		t.Error("modifying a clone must not modify the original map")
+	}
+}
+
+func TestGomapsFromIter(t *testing.T) {
+	m := New[string, int]()
+	Put(m, "a", 1)
+	Put(m, "b", 2)
+	got := gomaps.FromIter[string, int](Iter(m))
+	if want := map[string]int{"a": 1, "b": 2}; !maps.Equal(got, want) {
+		t.Errorf("gomaps.FromIter(Iter(%v)) = %v, want = %v", m, got, want)
+	}
+}
@@ -0,0 +1,41 @@
+package tsets
+
+import (
+	"github.com/shayanh/gcl/internal/avl"
+)
+
+func require(check bool, failMsg string) {
+	if !check {
+		panic(failMsg)
+	}
+}
+
+// FrwIter is a tree set forward iterator. It yields the set elements in
+// ascending order.
+type FrwIter[T any] struct {
+	impl *avl.Iterator[T, struct{}]
+}
+
+func (it *FrwIter[T]) HasNext() bool {
+	return it.impl.HasNext()
+}
+
+func (it *FrwIter[T]) Next() T {
+	require(it.HasNext(), "iterator must have next")
+	return it.impl.Next().Key
+}
+
+// RevIter is a tree set reverse iterator. It yields the set elements in
+// descending order.
+type RevIter[T any] struct {
+	impl *avl.Iterator[T, struct{}]
+}
+
+func (it *RevIter[T]) HasNext() bool {
+	return it.impl.HasNext()
+}
+
+func (it *RevIter[T]) Next() T {
+	require(it.HasNext(), "iterator must have next")
+	return it.impl.Next().Key
+}
@@ -0,0 +1,313 @@
+// Package tsets provides an ordered tree set and various functions useful
+// with ordered sets of any type.
+//
+// A set is not safe for concurrent use without external synchronization.
+package tsets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/internal/avl"
+	"github.com/shayanh/gcl/iters"
+	"golang.org/x/exp/constraints"
+)
+
+// Set is an ordered set implemented as a balanced binary search tree (AVL
+// tree). Elements are kept in ascending order as determined by the compare
+// function of the set. Each tree node also tracks the size of its subtree,
+// which makes order statistics (Rank and Select) O(log(n)).
+type Set[T any] struct {
+	tree *avl.Tree[T, struct{}]
+}
+
+// New creates a new set of any ordered type containing the given elements and
+// returns a pointer to it.
+func New[T constraints.Ordered](elems ...T) *Set[T] {
+	return NewFunc(gcl.Compare[T], elems...)
+}
+
+// NewFunc creates a new set whose elements are ordered by the `cmp` function,
+// adds the given elements to it, and returns a pointer to it.
+func NewFunc[T any](cmp gcl.CompareFn[T, T], elems ...T) *Set[T] {
+	s := &Set[T]{
+		tree: avl.New[T, struct{}](cmp),
+	}
+	Add(s, elems...)
+	return s
+}
+
+// FromIter builds a new set of any ordered type from the given iterator.
+func FromIter[T constraints.Ordered](it iters.Iterator[T]) *Set[T] {
+	s := New[T]()
+	for it.HasNext() {
+		Add(s, it.Next())
+	}
+	return s
+}
+
+func (s *Set[T]) String() string {
+	var b strings.Builder
+	b.WriteString("tsets.Set[")
+	it := Iter(s)
+	for it.HasNext() {
+		v := it.Next()
+		fmt.Fprintf(&b, "%v", v)
+		if it.HasNext() {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Len returns the number of elements in the given set. This function is O(1).
+func Len[T any](s *Set[T]) int {
+	return s.tree.Len()
+}
+
+// Add adds the given elements to set s. Elements that are already present are
+// ignored.
+// This function is O(len(elems) * log(n)), where n is size of the set.
+func Add[T any](s *Set[T], elems ...T) {
+	for _, elem := range elems {
+		s.tree.Put(elem, struct{}{})
+	}
+}
+
+// Remove removes the given elements from set s. Elements that are not present
+// are ignored.
+// This function is O(len(elems) * log(n)), where n is size of the set.
+func Remove[T any](s *Set[T], elems ...T) {
+	for _, elem := range elems {
+		s.tree.Delete(elem)
+	}
+}
+
+// Contains tests whether the given set s has value v.
+// This function is O(log(n)), where n is size of the set.
+func Contains[T any](s *Set[T], v T) bool {
+	return s.tree.Find(v) != nil
+}
+
+// Min returns the smallest element in the set. It requires the set to be
+// non-empty, otherwise it panics.
+// This function is O(log(n)), where n is size of the set.
+func Min[T any](s *Set[T]) T {
+	require(Len(s) > 0, "set cannot be empty")
+	return s.tree.Min().Key
+}
+
+// Max returns the largest element in the set. It requires the set to be
+// non-empty, otherwise it panics.
+// This function is O(log(n)), where n is size of the set.
+func Max[T any](s *Set[T]) T {
+	require(Len(s) > 0, "set cannot be empty")
+	return s.tree.Max().Key
+}
+
+// Floor returns the largest element less than or equal to v. The returned
+// boolean value indicates if such an element exists.
+// This function is O(log(n)), where n is size of the set.
+func Floor[T any](s *Set[T], v T) (T, bool) {
+	return lookup(s.tree.Floor(v))
+}
+
+// Ceiling returns the smallest element greater than or equal to v. The
+// returned boolean value indicates if such an element exists.
+// This function is O(log(n)), where n is size of the set.
+func Ceiling[T any](s *Set[T], v T) (T, bool) {
+	return lookup(s.tree.Ceiling(v))
+}
+
+// Rank returns the number of elements in the set that are less than v. v does
+// not need to be present in the set. If v is present, Rank returns its
+// zero-based position in ascending order, so Select(s, Rank(s, v)) == v.
+// This function is O(log(n)), where n is size of the set.
+func Rank[T any](s *Set[T], v T) int {
+	return s.tree.Rank(v)
+}
+
+// Select returns the k-th smallest element in the set, counting from zero. It
+// requires 0 <= k < Len(s), otherwise it panics.
+// This function is O(log(n)), where n is size of the set.
+func Select[T any](s *Set[T], k int) T {
+	require(0 <= k && k < Len(s), "index out of range")
+	return s.tree.Select(k).Key
+}
+
+// Iter returns a forward iterator over the elements of the given set in
+// ascending order.
+// Any insertion or deletion in the set invalidates the iterator.
+func Iter[T any](s *Set[T]) *FrwIter[T] {
+	return &FrwIter[T]{
+		impl: s.tree.Iter(),
+	}
+}
+
+// RIter returns a reverse iterator over the elements of the given set in
+// descending order.
+// Any insertion or deletion in the set invalidates the iterator.
+func RIter[T any](s *Set[T]) *RevIter[T] {
+	return &RevIter[T]{
+		impl: s.tree.RIter(),
+	}
+}
+
+// Range returns a forward iterator over the elements of the given set in the
+// half-open interval [lo, hi), in ascending order.
+// Creating the iterator is O(log(n)), where n is size of the set.
+// Any insertion or deletion in the set invalidates the iterator.
+func Range[T any](s *Set[T], lo, hi T) *FrwIter[T] {
+	return &FrwIter[T]{
+		impl: s.tree.Range(lo, hi),
+	}
+}
+
+// Clone returns a copy of the given set. The elements are copied using
+// assignment, so this is a shallow clone.
+// This function is O(n), where n is size of the set.
+func Clone[T any](s *Set[T]) *Set[T] {
+	return &Set[T]{
+		tree: s.tree.Clone(),
+	}
+}
+
+// Equal tests whether two sets contain the same elements. Both sets must be
+// ordered by the same compare function. Elements are compared using the
+// compare function of s1.
+// This function is O(min(Len(s1), Len(s2))).
+func Equal[T any](s1, s2 *Set[T]) bool {
+	if Len(s1) != Len(s2) {
+		return false
+	}
+	cmp := s1.tree.Cmp()
+	return iters.EqualFunc[T, T](Iter(s1), Iter(s2), func(a, b T) bool {
+		return cmp(a, b) == 0
+	})
+}
+
+// Union returns a new set containing the elements that are in s1, s2 or both.
+// Both sets must be ordered by the same compare function. The resulting set
+// uses the compare function of s1.
+// This function is O(Len(s1) + Len(s2)).
+func Union[T any](s1, s2 *Set[T]) *Set[T] {
+	return merge(s1, s2, true, true, true)
+}
+
+// Intersection returns a new set containing the elements that are in both s1
+// and s2. Both sets must be ordered by the same compare function. The
+// resulting set uses the compare function of s1.
+// This function is O(Len(s1) + Len(s2)).
+func Intersection[T any](s1, s2 *Set[T]) *Set[T] {
+	return merge(s1, s2, false, true, false)
+}
+
+// Difference returns a new set containing the elements of s1 that are not in
+// s2. Both sets must be ordered by the same compare function. The resulting
+// set uses the compare function of s1.
+// This function is O(Len(s1) + Len(s2)).
+func Difference[T any](s1, s2 *Set[T]) *Set[T] {
+	return merge(s1, s2, true, false, false)
+}
+
+// SymmetricDifference returns a new set containing the elements that are in
+// exactly one of s1 and s2. Both sets must be ordered by the same compare
+// function. The resulting set uses the compare function of s1.
+// This function is O(Len(s1) + Len(s2)).
+func SymmetricDifference[T any](s1, s2 *Set[T]) *Set[T] {
+	return merge(s1, s2, true, false, true)
+}
+
+// IsSubset tests whether every element of s1 is also in s2. Both sets must be
+// ordered by the same compare function.
+// This function is O(Len(s1) + Len(s2)).
+func IsSubset[T any](s1, s2 *Set[T]) bool {
+	if Len(s1) > Len(s2) {
+		return false
+	}
+	cmp := s1.tree.Cmp()
+	it1, it2 := s1.tree.Iter(), s2.tree.Iter()
+	for it1.HasNext() {
+		if !it2.HasNext() {
+			return false
+		}
+		switch c := cmp(it1.Peek().Key, it2.Peek().Key); {
+		case c < 0:
+			return false
+		case c > 0:
+			it2.Next()
+		default:
+			it1.Next()
+			it2.Next()
+		}
+	}
+	return true
+}
+
+// IsDisjoint tests whether s1 and s2 have no elements in common. Both sets
+// must be ordered by the same compare function.
+// This function is O(Len(s1) + Len(s2)).
+func IsDisjoint[T any](s1, s2 *Set[T]) bool {
+	cmp := s1.tree.Cmp()
+	it1, it2 := s1.tree.Iter(), s2.tree.Iter()
+	for it1.HasNext() && it2.HasNext() {
+		switch c := cmp(it1.Peek().Key, it2.Peek().Key); {
+		case c < 0:
+			it1.Next()
+		case c > 0:
+			it2.Next()
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// merge walks s1 and s2 in ascending order at the same time and collects the
+// elements that are only in s1, in both sets, or only in s2, as requested by
+// the flags. Since the collected elements are already sorted, the resulting
+// tree is built in linear time.
+func merge[T any](s1, s2 *Set[T], onlyFirst, both, onlySecond bool) *Set[T] {
+	cmp := s1.tree.Cmp()
+	var res []T
+	it1, it2 := s1.tree.Iter(), s2.tree.Iter()
+	for it1.HasNext() && it2.HasNext() {
+		n1, n2 := it1.Peek(), it2.Peek()
+		switch c := cmp(n1.Key, n2.Key); {
+		case c < 0:
+			if onlyFirst {
+				res = append(res, n1.Key)
+			}
+			it1.Next()
+		case c > 0:
+			if onlySecond {
+				res = append(res, n2.Key)
+			}
+			it2.Next()
+		default:
+			if both {
+				res = append(res, n1.Key)
+			}
+			it1.Next()
+			it2.Next()
+		}
+	}
+	for onlyFirst && it1.HasNext() {
+		res = append(res, it1.Next().Key)
+	}
+	for onlySecond && it2.HasNext() {
+		res = append(res, it2.Next().Key)
+	}
+	return &Set[T]{
+		tree: avl.FromSorted[T, struct{}](cmp, res, nil),
+	}
+}
+
+func lookup[T any](n *avl.Node[T, struct{}]) (v T, ok bool) {
+	if n == nil {
+		return
+	}
+	return n.Key, true
+}
@@ -0,0 +1,214 @@
+package tsets
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/shayanh/gcl"
+	"github.com/shayanh/gcl/goslices"
+	"golang.org/x/exp/slices"
+)
+
+func TestNew(t *testing.T) {
+	s := New(3, 1, 2, 3, 1)
+	if Len(s) != 3 {
+		t.Errorf("Len(%v) = %v, want = %v", s, Len(s), 3)
+	}
+	if got := goslices.FromIter[int](Iter(s)); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Iter(%v) yielded %v, want %v", s, got, []int{1, 2, 3})
+	}
+	if got := goslices.FromIter[int](RIter(s)); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("RIter(%v) yielded %v, want %v", s, got, []int{3, 2, 1})
+	}
+	if Min(s) != 1 || Max(s) != 3 {
+		t.Errorf("Min(%v), Max(%v) = %v, %v, want = 1, 3", s, s, Min(s), Max(s))
+	}
+}
+
+func TestAddRemove(t *testing.T) {
+	s := FromIter[int](goslices.Iter([]int{5, 4}))
+	Add(s, 1, 2, 3)
+	Remove(s, 2, 6)
+	if want := New(1, 3, 4, 5); !Equal(s, want) {
+		t.Errorf("got %v, want %v", s, want)
+	}
+	if Contains(s, 2) || !Contains(s, 3) {
+		t.Errorf("wrong Contains result for %v", s)
+	}
+}
+
+func TestNewFunc(t *testing.T) {
+	s := NewFunc(gcl.Compare[int], 1, 2, 3)
+	rev := NewFunc(func(a, b int) int { return gcl.Compare(b, a) }, 1, 2, 3)
+	if got := goslices.FromIter[int](Iter(rev)); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("Iter(%v) yielded %v, want %v", rev, got, []int{3, 2, 1})
+	}
+	if Rank(rev, 3) != 0 || Rank(s, 3) != 2 {
+		t.Errorf("wrong Rank for custom ordering")
+	}
+}
+
+var rankTests = []struct {
+	v    int
+	want int
+}{
+	{0, 0},
+	{10, 0},
+	{15, 1},
+	{20, 1},
+	{40, 3},
+	{50, 4},
+	{100, 5},
+}
+
+func TestRankSelect(t *testing.T) {
+	s := New(30, 10, 50, 20, 40)
+	for _, test := range rankTests {
+		if res := Rank(s, test.v); res != test.want {
+			t.Errorf("Rank(%v, %v) = %v, want = %v", s, test.v, res, test.want)
+		}
+	}
+	for k, want := range []int{10, 20, 30, 40, 50} {
+		if res := Select(s, k); res != want {
+			t.Errorf("Select(%v, %v) = %v, want = %v", s, k, res, want)
+		}
+	}
+}
+
+func TestRankSelectRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := New[int]()
+	ref := make(map[int]bool)
+	for i := 0; i < 3000; i++ {
+		v := r.Intn(1000)
+		if r.Intn(3) == 0 {
+			Remove(s, v)
+			delete(ref, v)
+		} else {
+			Add(s, v)
+			ref[v] = true
+		}
+	}
+	var sorted []int
+	for v := range ref {
+		sorted = append(sorted, v)
+	}
+	slices.Sort(sorted)
+	if Len(s) != len(sorted) {
+		t.Fatalf("Len(s) = %v, want = %v", Len(s), len(sorted))
+	}
+	for i, v := range sorted {
+		if res := Select(s, i); res != v {
+			t.Fatalf("Select(s, %v) = %v, want = %v", i, res, v)
+		}
+		if res := Rank(s, v); res != i {
+			t.Fatalf("Rank(s, %v) = %v, want = %v", v, res, i)
+		}
+	}
+}
+
+func TestFloorCeilingRange(t *testing.T) {
+	s := New(10, 20, 30)
+	if v, ok := Floor(s, 25); !ok || v != 20 {
+		t.Errorf("Floor(%v, 25) = (%v, %v), want = (20, true)", s, v, ok)
+	}
+	if _, ok := Floor(s, 5); ok {
+		t.Errorf("Floor(%v, 5) must not be ok", s)
+	}
+	if v, ok := Ceiling(s, 25); !ok || v != 30 {
+		t.Errorf("Ceiling(%v, 25) = (%v, %v), want = (30, true)", s, v, ok)
+	}
+	if _, ok := Ceiling(s, 35); ok {
+		t.Errorf("Ceiling(%v, 35) must not be ok", s)
+	}
+	if got := goslices.FromIter[int](Range(s, 15, 30)); !slices.Equal(got, []int{20}) {
+		t.Errorf("Range(%v, 15, 30) yielded %v, want %v", s, got, []int{20})
+	}
+}
+
+var algebraTests = []struct {
+	s1, s2                  *Set[int]
+	union, inter, diff, sym *Set[int]
+	subset, disjoint        bool
+}{
+	{
+		s1:       New[int](),
+		s2:       New[int](),
+		union:    New[int](),
+		inter:    New[int](),
+		diff:     New[int](),
+		sym:      New[int](),
+		subset:   true,
+		disjoint: true,
+	},
+	{
+		s1:       New(1, 2, 3),
+		s2:       New(2, 3, 4),
+		union:    New(1, 2, 3, 4),
+		inter:    New(2, 3),
+		diff:     New(1),
+		sym:      New(1, 4),
+		subset:   false,
+		disjoint: false,
+	},
+	{
+		s1:       New(1, 3),
+		s2:       New(1, 2, 3),
+		union:    New(1, 2, 3),
+		inter:    New(1, 3),
+		diff:     New[int](),
+		sym:      New(2),
+		subset:   true,
+		disjoint: false,
+	},
+	{
+		s1:       New(1, 2),
+		s2:       New(3, 4),
+		union:    New(1, 2, 3, 4),
+		inter:    New[int](),
+		diff:     New(1, 2),
+		sym:      New(1, 2, 3, 4),
+		subset:   false,
+		disjoint: true,
+	},
+}
+
+func TestAlgebra(t *testing.T) {
+	for _, test := range algebraTests {
+		if res := Union(test.s1, test.s2); !Equal(res, test.union) {
+			t.Errorf("Union(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.union)
+		}
+		if res := Intersection(test.s1, test.s2); !Equal(res, test.inter) {
+			t.Errorf("Intersection(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.inter)
+		}
+		if res := Difference(test.s1, test.s2); !Equal(res, test.diff) {
+			t.Errorf("Difference(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.diff)
+		}
+		if res := SymmetricDifference(test.s1, test.s2); !Equal(res, test.sym) {
+			t.Errorf("SymmetricDifference(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.sym)
+		}
+		if res := IsSubset(test.s1, test.s2); res != test.subset {
+			t.Errorf("IsSubset(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.subset)
+		}
+		if res := IsDisjoint(test.s1, test.s2); res != test.disjoint {
+			t.Errorf("IsDisjoint(%v, %v) = %v, want = %v", test.s1, test.s2, res, test.disjoint)
+		}
+	}
+
+	// The result of a merge must be a valid tree for later operations.
+	u := Union(New(1, 3, 5, 7), New(2, 4, 6))
+	Add(u, 8)
+	Remove(u, 1)
+	if Select(u, 0) != 2 || Rank(u, 8) != 6 {
+		t.Errorf("got %v after modifying a Union result", u)
+	}
+}
+
+func TestClone(t *testing.T) {
+	s := New(1, 2, 3)
+	cloned := Clone(s)
+	Add(cloned, 4)
+	if Contains(s, 4) || Len(cloned) != 4 {
+		t.Error("modifying a clone must not modify the original set")
+	}
+}